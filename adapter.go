@@ -0,0 +1,312 @@
+package gollections
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// anyCollection exposes a typed collection through the untyped CollectionOf[any] interface.
+type anyCollection[T any] struct {
+	collection CollectionOf[T]
+}
+
+// typed converts untyped values to the element type of the underlying collection.
+// Returns false if any value is not assignable to the element type.
+func typed[T any](values []interface{}) ([]T, bool) {
+	result := make([]T, len(values))
+	for i, value := range values {
+		if value == nil {
+			if !nillable[T]() {
+				return nil, false
+			}
+			continue
+		}
+		v, ok := value.(T)
+		if !ok {
+			return nil, false
+		}
+		result[i] = v
+	}
+	return result, true
+}
+
+// elemType gets the reflected type of T.
+func elemType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// nillable checks if nil is assignable to the type T.
+func nillable[T any]() bool {
+	switch elemType[T]().Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// Add appends new elements to the end of the collection.
+// Panics if a value is not of the underlying element type.
+func (c *anyCollection[T]) Add(values ...interface{}) {
+	v, ok := typed[T](values)
+	if !ok {
+		panic(fmt.Sprintf("gollections: cannot add %v to collection of %v", values, elemType[T]()))
+	}
+	c.collection.Add(v...)
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+func (c *anyCollection[T]) All() iter.Seq[interface{}] {
+	return anySeq(c.collection.All())
+}
+
+// Clear removes all elements from the collection.
+func (c *anyCollection[T]) Clear() {
+	c.collection.Clear()
+}
+
+// Contains checks if the collection contains all specified values.
+func (c *anyCollection[T]) Contains(values ...interface{}) bool {
+	v, ok := typed[T](values)
+	return ok && c.collection.Contains(v...)
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (c *anyCollection[T]) IsEmpty() bool {
+	return c.collection.IsEmpty()
+}
+
+// Iterator gets an iterator over the elements of the collection.
+func (c *anyCollection[T]) Iterator() Iterator[interface{}] {
+	return &anyIterator[T]{iterator: c.collection.Iterator()}
+}
+
+// Remove removes all specified values from the collection.
+// Values that are not of the underlying element type are ignored.
+func (c *anyCollection[T]) Remove(values ...interface{}) {
+	for _, value := range values {
+		if v, ok := typed[T]([]interface{}{value}); ok {
+			c.collection.Remove(v...)
+		}
+	}
+}
+
+// Size gets the number of elements in the collection.
+func (c *anyCollection[T]) Size() int {
+	return c.collection.Size()
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (c *anyCollection[T]) SliceCopy(ptrToSlice interface{}) error {
+	return c.collection.SliceCopy(ptrToSlice)
+}
+
+// ToArray gets an array representation of the collection.
+func (c *anyCollection[T]) ToArray() []interface{} {
+	values := c.collection.ToArray()
+	array := make([]interface{}, len(values))
+	for i, value := range values {
+		array[i] = value
+	}
+	return array
+}
+
+// anyList exposes a typed list through the untyped ListOf[any] interface.
+type anyList[T any] struct {
+	anyCollection[T]
+	list ListOf[T]
+}
+
+// Backward gets a sequence over the elements of the list in reverse order.
+func (l *anyList[T]) Backward() iter.Seq[interface{}] {
+	return anySeq(l.list.Backward())
+}
+
+// IndexOf gets the first occurance of the specified value or -1 if not found.
+func (l *anyList[T]) IndexOf(value interface{}) int {
+	v, ok := typed[T]([]interface{}{value})
+	if !ok {
+		return -1
+	}
+	return l.list.IndexOf(v[0])
+}
+
+// Insert adds elements at the specified index. Can return index not found error.
+func (l *anyList[T]) Insert(index int, values ...interface{}) error {
+	v, ok := typed[T](values)
+	if !ok {
+		return fmt.Errorf("cannot insert %v into list of %v", values, elemType[T]())
+	}
+	return l.list.Insert(index, v...)
+}
+
+// Get retrieves the value of the element at the specified index.
+func (l *anyList[T]) Get(index int) (interface{}, error) {
+	value, err := l.list.Get(index)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ListIterator gets a list iterator with its cursor before the first element of the list.
+func (l *anyList[T]) ListIterator() ListIterator[interface{}] {
+	it := l.list.ListIterator()
+	return &anyListIterator[T]{anyIterator: anyIterator[T]{iterator: it}, iterator: it}
+}
+
+// RemoveAt removes the element at the specified index.
+func (l *anyList[T]) RemoveAt(index int) error {
+	return l.list.RemoveAt(index)
+}
+
+// Set overwrites the value of the element at the specified index.
+func (l *anyList[T]) Set(index int, value interface{}) error {
+	v, ok := typed[T]([]interface{}{value})
+	if !ok {
+		return fmt.Errorf("cannot set %v in list of %v", value, elemType[T]())
+	}
+	return l.list.Set(index, v[0])
+}
+
+// AddAll appends the elements of the other collection to the end of the list.
+// Panics if a value is not of the underlying element type.
+func (l *anyList[T]) AddAll(other CollectionOf[interface{}]) {
+	l.Add(other.ToArray()...)
+}
+
+// InsertAll adds the elements of the other collection at the specified index.
+func (l *anyList[T]) InsertAll(index int, other CollectionOf[interface{}]) error {
+	return l.Insert(index, other.ToArray()...)
+}
+
+// RemoveIf removes all elements that match the predicate.
+func (l *anyList[T]) RemoveIf(predicate func(interface{}) bool) {
+	l.list.RemoveIf(func(value T) bool {
+		return predicate(value)
+	})
+}
+
+// RemoveRange removes the elements from the first index, inclusive, to the second index,
+// exclusive.
+func (l *anyList[T]) RemoveRange(from, to int) error {
+	return l.list.RemoveRange(from, to)
+}
+
+// ReplaceAll overwrites each element with the result of applying the operator to it.
+// Panics if a result is not of the underlying element type.
+func (l *anyList[T]) ReplaceAll(operator func(interface{}) interface{}) {
+	l.list.ReplaceAll(func(value T) T {
+		result := operator(value)
+		v, ok := typed[T]([]interface{}{result})
+		if !ok {
+			panic(fmt.Sprintf("gollections: cannot set %v in list of %v", result, elemType[T]()))
+		}
+		return v[0]
+	})
+}
+
+// RetainAll removes all elements that are not in the other collection.
+func (l *anyList[T]) RetainAll(other CollectionOf[interface{}]) {
+	l.list.RemoveIf(func(value T) bool {
+		return !other.Contains(value)
+	})
+}
+
+// SubList gets a view of the elements from the first index, inclusive, to the second index,
+// exclusive.
+func (l *anyList[T]) SubList(from, to int) (ListOf[interface{}], error) {
+	view, err := l.list.SubList(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return AnyList(view), nil
+}
+
+// anySeq converts a typed sequence to an untyped sequence.
+func anySeq[T any](seq iter.Seq[T]) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for value := range seq {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// anyIterator exposes a typed iterator through the untyped Iterator[any] interface.
+type anyIterator[T any] struct {
+	iterator Iterator[T]
+}
+
+func (i *anyIterator[T]) HasNext() bool {
+	return i.iterator.HasNext()
+}
+
+func (i *anyIterator[T]) Next() (interface{}, error) {
+	value, err := i.iterator.Next()
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (i *anyIterator[T]) Remove() error {
+	return i.iterator.Remove()
+}
+
+// anyListIterator exposes a typed list iterator through the untyped ListIterator[any] interface.
+type anyListIterator[T any] struct {
+	anyIterator[T]
+	iterator ListIterator[T]
+}
+
+func (i *anyListIterator[T]) HasPrevious() bool {
+	return i.iterator.HasPrevious()
+}
+
+func (i *anyListIterator[T]) Previous() (interface{}, error) {
+	value, err := i.iterator.Previous()
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (i *anyListIterator[T]) NextIndex() int {
+	return i.iterator.NextIndex()
+}
+
+func (i *anyListIterator[T]) PreviousIndex() int {
+	return i.iterator.PreviousIndex()
+}
+
+func (i *anyListIterator[T]) Set(value interface{}) error {
+	v, ok := typed[T]([]interface{}{value})
+	if !ok {
+		return fmt.Errorf("cannot set %v in list of %v", value, elemType[T]())
+	}
+	return i.iterator.Set(v[0])
+}
+
+func (i *anyListIterator[T]) Add(value interface{}) error {
+	v, ok := typed[T]([]interface{}{value})
+	if !ok {
+		return fmt.Errorf("cannot add %v to list of %v", value, elemType[T]())
+	}
+	return i.iterator.Add(v[0])
+}
+
+// AnyCollection exposes a typed collection as a CollectionOf[any] so that it may be passed to code
+// that has not yet migrated to typed collections. Changes made through the returned collection
+// are applied to the supplied collection.
+func AnyCollection[T any](collection CollectionOf[T]) CollectionOf[any] {
+	return &anyCollection[T]{collection: collection}
+}
+
+// AnyList exposes a typed list as a ListOf[any] so that it may be passed to code that has not yet
+// migrated to typed lists. Changes made through the returned list are applied to the supplied
+// list.
+func AnyList[T any](list ListOf[T]) ListOf[any] {
+	return &anyList[T]{anyCollection: anyCollection[T]{collection: list}, list: list}
+}
@@ -0,0 +1,118 @@
+package gollections_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// TestAnyList tests exposing a typed list through the untyped list interface.
+func TestAnyList(t *testing.T) {
+	typed := gollections.NewLinkedListOf[int]()
+	list := gollections.AnyList(typed)
+	list.Add(1, 2, 3)
+	if got, err := typed.Get(2); err != nil || got != 3 {
+		t.Fatalf("expected 3, got %d, err: %v", got, err)
+	}
+	if got, err := list.Get(0); err != nil || got != 1 {
+		t.Fatalf("expected 1, got %v, err: %v", got, err)
+	}
+	if !list.Contains(1, 3) || list.Contains(1, "foo") {
+		t.Fatal("expected contains to match only values of the element type")
+	}
+	if index := list.IndexOf("foo"); index != -1 {
+		t.Fatalf("expected -1, got %d", index)
+	}
+	if err := list.Set(0, "foo"); err == nil {
+		t.Fatal("expected type error")
+	}
+	if err := list.Insert(0, 2.5); err == nil {
+		t.Fatal("expected type error")
+	}
+	list.Remove("foo", 2)
+	expected := []interface{}{1, 3}
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected add to panic on a value of the wrong type")
+		}
+	}()
+	list.Add("foo")
+}
+
+// TestAnyCollectionNil tests passing nil through the untyped collection interface.
+func TestAnyCollectionNil(t *testing.T) {
+	collection := gollections.AnyCollection(gollections.NewLinkedCollectionOf[*int]())
+	collection.Add(nil)
+	if !collection.Contains(nil) {
+		t.Fatal("expected collection to contain nil")
+	}
+	if size := collection.Size(); size != 1 {
+		t.Fatalf("expected size 1, got %d", size)
+	}
+}
+
+// TestAnyListIterator tests iterating a typed list through the untyped list interface.
+func TestAnyListIterator(t *testing.T) {
+	list := gollections.AnyList(gollections.NewArrayListOf[string]())
+	list.Add("a", "b")
+	expected := []interface{}{"b", "a"}
+	got := []interface{}{}
+	for value := range list.Backward() {
+		got = append(got, value)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	it := list.ListIterator()
+	if got, err := it.Next(); err != nil || got != "a" {
+		t.Fatalf("expected a, got %v, err: %v", got, err)
+	}
+	if err := it.Set(1); err == nil {
+		t.Fatal("expected type error")
+	}
+	if err := it.Add("c"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected = []interface{}{"a", "c", "b"}
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestAnyListBulk tests the bulk operations of an untyped list.
+func TestAnyListBulk(t *testing.T) {
+	typed := gollections.NewArrayListOf[int]()
+	list := gollections.AnyList(typed)
+	other := gollections.NewArrayListOf[any]()
+	other.Add(1, 2, 3, 4)
+	list.AddAll(other)
+	list.RemoveIf(func(value any) bool { return value == 2 })
+	list.ReplaceAll(func(value any) any { return value.(int) * 2 })
+	keep := gollections.NewLinkedListOf[any]()
+	keep.Add(2, 8, "foo")
+	list.RetainAll(keep)
+	view, err := list.SubList(1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, err := view.Get(0); err != nil || got != 8 {
+		t.Fatalf("expected 8, got %v, err: %v", got, err)
+	}
+	if err := view.InsertAll(0, other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []int{2, 1, 2, 3, 4, 8}
+	if got := typed.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected replace all to panic on a value of the wrong type")
+		}
+	}()
+	list.ReplaceAll(func(value any) any { return "foo" })
+}
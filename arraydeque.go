@@ -0,0 +1,446 @@
+package gollections
+
+import "iter"
+
+// minDequeCapacity is the smallest buffer allocated by a growable ArrayDeque.
+const minDequeCapacity = 8
+
+// ArrayDeque is an implementation of a deque backed by a circular buffer.
+// Adding and removing elements at either end and accessing elements by index are O(1).
+// An ArrayDeque may have a fixed capacity, in which case adding an element to one end of a full
+// deque discards the element at the other end.
+// The zero value is an empty, growable deque ready to use.
+type ArrayDeque[T any] struct {
+	values   []T
+	head     int
+	length   int
+	maxSize  int
+	equality Equaler[T]
+	modCount int
+}
+
+// equalityStrategy gets the equality strategy of the deque.
+func (d *ArrayDeque[T]) equalityStrategy() Equaler[T] {
+	return d.equality
+}
+
+// emptyCollection initializes an empty deque with the same configuration. The deque only has the
+// same fixed size if bounded is true.
+func (d *ArrayDeque[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	maxSize := 0
+	if bounded {
+		maxSize = d.maxSize
+	}
+	result := newArrayDeque[T](maxSize, maxSize, nil)
+	result.equality = d.equality
+	return result
+}
+
+// at converts an index in the deque to an index in the buffer.
+func (d *ArrayDeque[T]) at(index int) int {
+	return (d.head + index) % len(d.values)
+}
+
+// resize moves all elements to a new buffer of the specified capacity.
+func (d *ArrayDeque[T]) resize(capacity int) {
+	values := make([]T, capacity)
+	d.copyTo(values)
+	d.values = values
+	d.head = 0
+}
+
+// copyTo copies the elements of the deque in order to the start of the supplied slice.
+func (d *ArrayDeque[T]) copyTo(values []T) {
+	if d.length == 0 {
+		return
+	}
+	end := d.head + d.length
+	if end <= len(d.values) {
+		copy(values, d.values[d.head:end])
+		return
+	}
+	n := copy(values, d.values[d.head:])
+	copy(values[n:], d.values[:end-len(d.values)])
+}
+
+// contiguous gets the elements of a deque as a single slice of its buffer.
+func (d *ArrayDeque[T]) contiguous() []T {
+	if d.head+d.length > len(d.values) {
+		d.resize(len(d.values))
+	}
+	return d.values[d.head : d.head+d.length]
+}
+
+// ensureCapacity makes room for n more elements.
+// Returns false if the deque has a fixed capacity that would be exceeded.
+func (d *ArrayDeque[T]) ensureCapacity(n int) bool {
+	required := d.length + n
+	if d.maxSize > 0 {
+		if required > d.maxSize {
+			return false
+		}
+		if len(d.values) < d.maxSize {
+			d.resize(d.maxSize)
+		}
+		return true
+	}
+	if required <= len(d.values) {
+		return true
+	}
+	capacity := len(d.values) * 2
+	if capacity < minDequeCapacity {
+		capacity = minDequeCapacity
+	}
+	for capacity < required {
+		capacity *= 2
+	}
+	d.resize(capacity)
+	return true
+}
+
+// addLast appends a single element, discarding the first element if the deque is full.
+func (d *ArrayDeque[T]) addLast(value T) {
+	if !d.ensureCapacity(1) {
+		d.PopFirst()
+	}
+	d.values[d.at(d.length)] = value
+	d.length++
+	d.modCount++
+}
+
+// addFirst prepends a single element, discarding the last element if the deque is full.
+func (d *ArrayDeque[T]) addFirst(value T) {
+	if !d.ensureCapacity(1) {
+		d.PopLast()
+	}
+	d.head = (d.head - 1 + len(d.values)) % len(d.values)
+	d.values[d.head] = value
+	d.length++
+	d.modCount++
+}
+
+// Add appends new elements to the end of the collection.
+func (d *ArrayDeque[T]) Add(values ...T) {
+	if d.maxSize == 0 {
+		d.ensureCapacity(len(values))
+	}
+	for _, value := range values {
+		d.addLast(value)
+	}
+}
+
+// Clear removes all elements from the collection. The capacity of the deque is retained.
+func (d *ArrayDeque[T]) Clear() {
+	clear(d.values)
+	d.head = 0
+	d.length = 0
+	d.modCount++
+}
+
+// Contains checks if the collection contains all specified values.
+func (d *ArrayDeque[T]) Contains(values ...T) bool {
+	return containsAll(d.equality, d.ToArray(), values)
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (d *ArrayDeque[T]) IsEmpty() bool {
+	return d.length == 0
+}
+
+// Remove removes all specified values from the collection.
+func (d *ArrayDeque[T]) Remove(values ...T) {
+	d.RemoveIf(matcher(d.equality, values))
+}
+
+// Size gets the number of elements in the collection.
+func (d *ArrayDeque[T]) Size() int {
+	return d.length
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (d *ArrayDeque[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, d.ToArray())
+}
+
+// ToArray gets an array representation of the collection.
+func (d *ArrayDeque[T]) ToArray() []T {
+	array := make([]T, d.length)
+	d.copyTo(array)
+	return array
+}
+
+// IndexOf gets the first occurance of the specified value or -1 if not found.
+func (d *ArrayDeque[T]) IndexOf(value T) int {
+	equality := equalityOrDefault(d.equality)
+	for i := 0; i < d.length; i++ {
+		if equality.Equal(value, d.values[d.at(i)]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Insert adds elements at the specified index. Can return index not found error.
+// Returns a capacity exceeded error if the deque has a fixed capacity that is too small to hold
+// the new elements.
+func (d *ArrayDeque[T]) Insert(index int, values ...T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if index < 0 || index >= d.length {
+		return ErrIndexOutOfBounds
+	}
+	if !d.ensureCapacity(len(values)) {
+		return ErrCapacityExceeded
+	}
+	n := len(values)
+	for i := d.length - 1; i >= index; i-- {
+		d.values[d.at(i+n)] = d.values[d.at(i)]
+	}
+	for i, value := range values {
+		d.values[d.at(index+i)] = value
+	}
+	d.length += n
+	d.modCount++
+	return nil
+}
+
+// Get retrieves the value of the element at the specified index.
+func (d *ArrayDeque[T]) Get(index int) (T, error) {
+	if index < 0 || index >= d.length {
+		var zero T
+		return zero, ErrIndexOutOfBounds
+	}
+	return d.values[d.at(index)], nil
+}
+
+// RemoveAt removes the element at the specified index.
+// Elements are shifted from whichever end of the deque is closer to the index.
+func (d *ArrayDeque[T]) RemoveAt(index int) error {
+	if index < 0 || index >= d.length {
+		return ErrIndexOutOfBounds
+	}
+	var zero T
+	if index < d.length/2 {
+		for i := index; i > 0; i-- {
+			d.values[d.at(i)] = d.values[d.at(i-1)]
+		}
+		d.values[d.head] = zero
+		d.head = d.at(1)
+	} else {
+		for i := index; i < d.length-1; i++ {
+			d.values[d.at(i)] = d.values[d.at(i+1)]
+		}
+		d.values[d.at(d.length-1)] = zero
+	}
+	d.length--
+	d.modCount++
+	return nil
+}
+
+// Set overwrites the value of the element at the specified index.
+func (d *ArrayDeque[T]) Set(index int, value T) error {
+	if index < 0 || index >= d.length {
+		return ErrIndexOutOfBounds
+	}
+	d.values[d.at(index)] = value
+	return nil
+}
+
+// AddAll appends the elements of the other collection to the end of the deque.
+func (d *ArrayDeque[T]) AddAll(other CollectionOf[T]) {
+	d.Add(other.ToArray()...)
+}
+
+// InsertAll adds the elements of the other collection at the specified index. Can return index
+// not found error or capacity exceeded error.
+func (d *ArrayDeque[T]) InsertAll(index int, other CollectionOf[T]) error {
+	return d.Insert(index, other.ToArray()...)
+}
+
+// RemoveIf removes all elements that match the predicate in a single pass.
+func (d *ArrayDeque[T]) RemoveIf(predicate func(T) bool) {
+	kept := 0
+	for i := 0; i < d.length; i++ {
+		value := d.values[d.at(i)]
+		if predicate(value) {
+			continue
+		}
+		d.values[d.at(kept)] = value
+		kept++
+	}
+	if kept == d.length {
+		return
+	}
+	var zero T
+	for i := kept; i < d.length; i++ {
+		d.values[d.at(i)] = zero
+	}
+	d.length = kept
+	d.modCount++
+}
+
+// RemoveRange removes the elements from the first index, inclusive, to the second index,
+// exclusive. Can return index not found error.
+func (d *ArrayDeque[T]) RemoveRange(from, to int) error {
+	if from < 0 || to > d.length || from > to {
+		return ErrIndexOutOfBounds
+	}
+	if from == to {
+		return nil
+	}
+	values := d.contiguous()
+	n := copy(values[from:], values[to:])
+	clear(values[from+n:])
+	d.length -= to - from
+	d.modCount++
+	return nil
+}
+
+// ReplaceAll overwrites each element with the result of applying the operator to it.
+func (d *ArrayDeque[T]) ReplaceAll(operator func(T) T) {
+	for i := 0; i < d.length; i++ {
+		d.values[d.at(i)] = operator(d.values[d.at(i)])
+	}
+}
+
+// RetainAll removes all elements that are not in the other collection in a single pass.
+func (d *ArrayDeque[T]) RetainAll(other CollectionOf[T]) {
+	contains := membership(d.equality, other)
+	d.RemoveIf(func(value T) bool {
+		return !contains(value)
+	})
+}
+
+// SubList gets a view of the elements from the first index, inclusive, to the second index,
+// exclusive.
+func (d *ArrayDeque[T]) SubList(from, to int) (ListOf[T], error) {
+	return newSubList[T](d, &d.modCount, nil, from, to)
+}
+
+// PeekFirst gets the value of the first element in the collection.
+func (d *ArrayDeque[T]) PeekFirst() (T, error) {
+	if d.length == 0 {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	return d.values[d.head], nil
+}
+
+// PopFirst gets the value of the first element in the collection. The element is removed.
+func (d *ArrayDeque[T]) PopFirst() (T, error) {
+	value, err := d.PeekFirst()
+	if err != nil {
+		return value, err
+	}
+	var zero T
+	d.values[d.head] = zero
+	d.head = d.at(1)
+	d.length--
+	d.modCount++
+	return value, nil
+}
+
+// PeekLast gets the value of the last element in the collection.
+func (d *ArrayDeque[T]) PeekLast() (T, error) {
+	if d.length == 0 {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	return d.values[d.at(d.length-1)], nil
+}
+
+// PopLast gets the value of the last element in the collection. The element is removed.
+func (d *ArrayDeque[T]) PopLast() (T, error) {
+	value, err := d.PeekLast()
+	if err != nil {
+		return value, err
+	}
+	var zero T
+	d.values[d.at(d.length-1)] = zero
+	d.length--
+	d.modCount++
+	return value, nil
+}
+
+// AddFirst adds new elements to the beginning of the collection.
+func (d *ArrayDeque[T]) AddFirst(values ...T) {
+	if d.maxSize == 0 {
+		d.ensureCapacity(len(values))
+	}
+	for _, value := range values {
+		d.addFirst(value)
+	}
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+// The sequence panics if the collection is structurally modified during iteration.
+func (d *ArrayDeque[T]) All() iter.Seq[T] {
+	return forward(d.Iterator)
+}
+
+// Backward gets a sequence over the elements of the deque in reverse order.
+// The sequence panics if the deque is structurally modified during iteration.
+func (d *ArrayDeque[T]) Backward() iter.Seq[T] {
+	return backward(func() ListIterator[T] {
+		return newIndexIterator[T](d, &d.modCount, d.Size())
+	})
+}
+
+// Iterator gets an iterator over the elements of the collection.
+func (d *ArrayDeque[T]) Iterator() Iterator[T] {
+	return d.ListIterator()
+}
+
+// ListIterator gets a list iterator with its cursor before the first element of the deque.
+func (d *ArrayDeque[T]) ListIterator() ListIterator[T] {
+	return newIndexIterator[T](d, &d.modCount, 0)
+}
+
+// Cap gets the number of elements the deque can hold without reallocating.
+func (d *ArrayDeque[T]) Cap() int {
+	if d.maxSize > 0 {
+		return d.maxSize
+	}
+	return len(d.values)
+}
+
+// IsFull checks if the deque has a fixed capacity and holds that many elements.
+func (d *ArrayDeque[T]) IsFull() bool {
+	return d.maxSize > 0 && d.length == d.maxSize
+}
+
+// newArrayDeque initializes a circular buffer with the supplied capacity and options.
+func newArrayDeque[T any](capacity, maxSize int, opts []Option[T]) *ArrayDeque[T] {
+	d := &ArrayDeque[T]{maxSize: maxSize, equality: newOptions(opts).equality}
+	if capacity > 0 {
+		d.values = make([]T, capacity)
+	}
+	return d
+}
+
+// NewArrayQueueOf initializes a queue backed by a circular buffer.
+func NewArrayQueueOf[T any](options ...Option[T]) QueueOf[T] {
+	return newArrayDeque(0, 0, options)
+}
+
+// NewArrayDequeOf initializes a deque backed by a circular buffer.
+func NewArrayDequeOf[T any](options ...Option[T]) DequeOf[T] {
+	return newArrayDeque(0, 0, options)
+}
+
+// NewArrayDequeOfCapacity initializes a deque backed by a circular buffer that can hold the
+// specified number of elements without reallocating.
+func NewArrayDequeOfCapacity[T any](capacity int, options ...Option[T]) *ArrayDeque[T] {
+	return newArrayDeque(capacity, 0, options)
+}
+
+// NewFixedArrayDequeOf initializes a deque backed by a circular buffer that holds at most the
+// specified number of elements. Adding an element to one end of a full deque discards the element
+// at the other end. A capacity less than one is treated as one.
+func NewFixedArrayDequeOf[T any](capacity int, options ...Option[T]) *ArrayDeque[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return newArrayDeque(capacity, capacity, options)
+}
@@ -0,0 +1,154 @@
+package gollections_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the ArrayDeque as an implementation of Collection.
+func TestArrayDequeCollection(t *testing.T) {
+	testCollection(t, gollections.NewArrayDequeOf[any]())
+}
+
+// TestArrayDequeSliceCopy tests the slice copy function for the ArrayDeque implementation of
+// Collection.
+func TestArrayDequeSliceCopy(t *testing.T) {
+	testCollectionSliceCopy(t, gollections.NewArrayDequeOf[any]())
+}
+
+// Test the ArrayDeque as an implementation of List.
+func TestArrayDequeList(t *testing.T) {
+	testList(t, &gollections.ArrayDeque[any]{})
+}
+
+// Test the ArrayDeque as an implementation of Queue.
+func TestArrayQueue(t *testing.T) {
+	testQueue(t, gollections.NewArrayQueueOf[any]())
+}
+
+// Test the ArrayDeque as an implementation of Stack.
+func TestArrayDequeStack(t *testing.T) {
+	testStack(t, &gollections.ArrayDeque[any]{})
+}
+
+// Test the ArrayDeque as an implementation of Deque.
+func TestArrayDeque(t *testing.T) {
+	testDeque(t, gollections.NewArrayDequeOf[any]())
+}
+
+// TestArrayDequeTyped tests the ArrayDeque with a concrete element type.
+func TestArrayDequeTyped(t *testing.T) {
+	testListTyped(t, &gollections.ArrayDeque[string]{})
+}
+
+// TestArrayDequeEmptied tests that an ArrayDeque emptied from either end holds no stale elements.
+func TestArrayDequeEmptied(t *testing.T) {
+	testDequeEmptied(t, gollections.NewArrayDequeOf[int]())
+}
+
+// TestArrayDequeWrap tests an ArrayDeque whose elements wrap around the end of its buffer.
+func TestArrayDequeWrap(t *testing.T) {
+	deque := gollections.NewArrayDequeOfCapacity[int](4)
+	deque.Add(1, 2, 3)
+	deque.PopFirst()
+	deque.PopFirst()
+	deque.Add(4, 5)
+	deque.AddFirst(0)
+	expected := []int{0, 3, 4, 5}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if c := deque.Cap(); c != 4 {
+		t.Fatalf("expected capacity 4, got %d", c)
+	}
+	// grow while wrapped
+	deque.Add(6)
+	expected = []int{0, 3, 4, 5, 6}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// insert, remove at
+	deque.Insert(1, 1, 2)
+	deque.RemoveAt(5)
+	deque.RemoveAt(0)
+	expected = []int{1, 2, 3, 4, 6}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i, value := range expected {
+		if got, err := deque.Get(i); err != nil || got != value {
+			t.Fatalf("expected %d, got %d, err: %v", value, got, err)
+		}
+	}
+}
+
+// TestFixedArrayDeque tests an ArrayDeque with a fixed capacity.
+func TestFixedArrayDeque(t *testing.T) {
+	deque := gollections.NewFixedArrayDequeOf[int](3)
+	deque.Add(1, 2, 3)
+	if !deque.IsFull() {
+		t.Fatal("expected deque to be full")
+	}
+	// add discards from the front
+	deque.Add(4)
+	expected := []int{2, 3, 4}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// add first discards from the back
+	deque.AddFirst(1)
+	expected = []int{1, 2, 3}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if err := deque.Insert(1, 5); err != gollections.ErrCapacityExceeded {
+		t.Fatalf("expected capacity exceeded error, got %v", err)
+	}
+	if c := deque.Cap(); c != 3 {
+		t.Fatalf("expected capacity 3, got %d", c)
+	}
+	deque.PopLast()
+	if deque.IsFull() {
+		t.Fatal("expected deque not to be full")
+	}
+	if err := deque.Insert(1, 5); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected = []int{1, 5, 2}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestArrayDequeListIterator tests the iterators of the ArrayDeque.
+func TestArrayDequeListIterator(t *testing.T) {
+	testListIterator(t, &gollections.ArrayDeque[int]{})
+}
+
+// TestArrayDequeBulk tests the bulk operations of the ArrayDeque.
+func TestArrayDequeBulk(t *testing.T) {
+	testListBulk(t, &gollections.ArrayDeque[int]{})
+}
+
+// TestArrayDequeSubList tests sub list views of the ArrayDeque.
+func TestArrayDequeSubList(t *testing.T) {
+	testSubList(t, &gollections.ArrayDeque[int]{})
+	// views of a full fixed size deque do not drop its head
+	deque := gollections.NewFixedArrayDequeOf[int](3)
+	deque.Add(1, 2, 3)
+	view, _ := deque.SubList(1, 3)
+	if err := view.Insert(0, 4); err != gollections.ErrCapacityExceeded {
+		t.Fatalf("expected capacity exceeded error, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r != gollections.ErrCapacityExceeded {
+			t.Fatalf("expected capacity exceeded panic, got %v", r)
+		}
+		if got := deque.ToArray(); !reflect.DeepEqual([]int{1, 2, 3}, got) || view.Size() != 2 {
+			t.Fatalf("expected [1 2 3], got %v", got)
+		}
+	}()
+	view.Add(4)
+}
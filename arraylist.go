@@ -0,0 +1,304 @@
+package gollections
+
+import (
+	"iter"
+	"slices"
+)
+
+// ArrayList is an implementation of a list backed by a growable slice.
+// Appending is amortized O(1) and elements can be accessed by index in O(1). Adding or removing
+// elements anywhere other than the end of the list requires shifting the elements that follow.
+// The zero value is an empty list ready to use.
+type ArrayList[T any] struct {
+	values   []T
+	equality Equaler[T]
+	modCount int
+}
+
+// equalityStrategy gets the equality strategy of the list.
+func (l *ArrayList[T]) equalityStrategy() Equaler[T] {
+	return l.equality
+}
+
+// emptyCollection initializes an empty list with the same configuration.
+func (l *ArrayList[T]) emptyCollection(bool) CollectionOf[T] {
+	return &ArrayList[T]{equality: l.equality}
+}
+
+// Add appends new elements to the end of the collection.
+func (l *ArrayList[T]) Add(values ...T) {
+	l.values = append(l.values, values...)
+	l.modCount++
+}
+
+// Clear removes all elements from the collection. The capacity of the list is retained.
+func (l *ArrayList[T]) Clear() {
+	clear(l.values)
+	l.values = l.values[:0]
+	l.modCount++
+}
+
+// Contains checks if the collection contains all specified values.
+func (l *ArrayList[T]) Contains(values ...T) bool {
+	return containsAll(l.equality, l.values, values)
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (l *ArrayList[T]) IsEmpty() bool {
+	return len(l.values) == 0
+}
+
+// Remove removes all specified values from the collection.
+func (l *ArrayList[T]) Remove(values ...T) {
+	l.RemoveIf(matcher(l.equality, values))
+}
+
+// Size gets the number of elements in the collection.
+func (l *ArrayList[T]) Size() int {
+	return len(l.values)
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (l *ArrayList[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, l.values)
+}
+
+// ToArray gets an array representation of the collection.
+func (l *ArrayList[T]) ToArray() []T {
+	array := make([]T, len(l.values))
+	copy(array, l.values)
+	return array
+}
+
+// IndexOf gets the first occurance of the specified value or -1 if not found.
+func (l *ArrayList[T]) IndexOf(value T) int {
+	return indexOf(l.equality, l.values, value)
+}
+
+// Insert adds elements at the specified index. Can return index not found error.
+func (l *ArrayList[T]) Insert(index int, values ...T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if index < 0 || index >= len(l.values) {
+		return ErrIndexOutOfBounds
+	}
+	l.values = slices.Insert(l.values, index, values...)
+	l.modCount++
+	return nil
+}
+
+// Get retrieves the value of the element at the specified index.
+func (l *ArrayList[T]) Get(index int) (T, error) {
+	if index < 0 || index >= len(l.values) {
+		var zero T
+		return zero, ErrIndexOutOfBounds
+	}
+	return l.values[index], nil
+}
+
+// RemoveAt removes the element at the specified index.
+func (l *ArrayList[T]) RemoveAt(index int) error {
+	if index < 0 || index >= len(l.values) {
+		return ErrIndexOutOfBounds
+	}
+	l.values = slices.Delete(l.values, index, index+1)
+	l.modCount++
+	return nil
+}
+
+// Set overwrites the value of the element at the specified index.
+func (l *ArrayList[T]) Set(index int, value T) error {
+	if index < 0 || index >= len(l.values) {
+		return ErrIndexOutOfBounds
+	}
+	l.values[index] = value
+	return nil
+}
+
+// AddAll appends the elements of the other collection to the end of the list.
+func (l *ArrayList[T]) AddAll(other CollectionOf[T]) {
+	l.Add(other.ToArray()...)
+}
+
+// InsertAll adds the elements of the other collection at the specified index. Can return index
+// not found error.
+func (l *ArrayList[T]) InsertAll(index int, other CollectionOf[T]) error {
+	return l.Insert(index, other.ToArray()...)
+}
+
+// RemoveIf removes all elements that match the predicate in a single pass.
+func (l *ArrayList[T]) RemoveIf(predicate func(T) bool) {
+	size := len(l.values)
+	l.values = slices.DeleteFunc(l.values, predicate)
+	if len(l.values) != size {
+		l.modCount++
+	}
+}
+
+// RemoveRange removes the elements from the first index, inclusive, to the second index,
+// exclusive. Can return index not found error.
+func (l *ArrayList[T]) RemoveRange(from, to int) error {
+	if from < 0 || to > len(l.values) || from > to {
+		return ErrIndexOutOfBounds
+	}
+	if from == to {
+		return nil
+	}
+	l.values = slices.Delete(l.values, from, to)
+	l.modCount++
+	return nil
+}
+
+// ReplaceAll overwrites each element with the result of applying the operator to it.
+func (l *ArrayList[T]) ReplaceAll(operator func(T) T) {
+	for i, value := range l.values {
+		l.values[i] = operator(value)
+	}
+}
+
+// RetainAll removes all elements that are not in the other collection in a single pass.
+func (l *ArrayList[T]) RetainAll(other CollectionOf[T]) {
+	contains := membership(l.equality, other)
+	l.RemoveIf(func(value T) bool {
+		return !contains(value)
+	})
+}
+
+// SubList gets a view of the elements from the first index, inclusive, to the second index,
+// exclusive.
+func (l *ArrayList[T]) SubList(from, to int) (ListOf[T], error) {
+	return newSubList[T](l, &l.modCount, nil, from, to)
+}
+
+// PeekFirst gets the value of the first element in the collection.
+func (l *ArrayList[T]) PeekFirst() (T, error) {
+	if len(l.values) == 0 {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	return l.values[0], nil
+}
+
+// PopFirst gets the value of the first element in the collection. The element is removed.
+// All remaining elements are shifted toward the front of the list, an ArrayDeque can remove the
+// first element in O(1).
+func (l *ArrayList[T]) PopFirst() (T, error) {
+	value, err := l.PeekFirst()
+	if err != nil {
+		return value, err
+	}
+	l.values = slices.Delete(l.values, 0, 1)
+	l.modCount++
+	return value, nil
+}
+
+// PeekLast gets the value of the last element in the collection.
+func (l *ArrayList[T]) PeekLast() (T, error) {
+	if len(l.values) == 0 {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	return l.values[len(l.values)-1], nil
+}
+
+// PopLast gets the value of the last element in the collection. The element is removed.
+func (l *ArrayList[T]) PopLast() (T, error) {
+	value, err := l.PeekLast()
+	if err != nil {
+		return value, err
+	}
+	var zero T
+	l.values[len(l.values)-1] = zero
+	l.values = l.values[:len(l.values)-1]
+	l.modCount++
+	return value, nil
+}
+
+// AddFirst adds new elements to the beginning of the collection.
+// Each value is added in turn, so the values end up in reverse order.
+func (l *ArrayList[T]) AddFirst(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+	reversed := slices.Clone(values)
+	slices.Reverse(reversed)
+	l.values = slices.Insert(l.values, 0, reversed...)
+	l.modCount++
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+// The sequence panics if the collection is structurally modified during iteration.
+func (l *ArrayList[T]) All() iter.Seq[T] {
+	return forward(l.Iterator)
+}
+
+// Backward gets a sequence over the elements of the list in reverse order.
+// The sequence panics if the list is structurally modified during iteration.
+func (l *ArrayList[T]) Backward() iter.Seq[T] {
+	return backward(func() ListIterator[T] {
+		return newIndexIterator[T](l, &l.modCount, l.Size())
+	})
+}
+
+// Iterator gets an iterator over the elements of the collection.
+func (l *ArrayList[T]) Iterator() Iterator[T] {
+	return l.ListIterator()
+}
+
+// ListIterator gets a list iterator with its cursor before the first element of the list.
+func (l *ArrayList[T]) ListIterator() ListIterator[T] {
+	return newIndexIterator[T](l, &l.modCount, 0)
+}
+
+// Cap gets the number of elements the list can hold without reallocating.
+func (l *ArrayList[T]) Cap() int {
+	return cap(l.values)
+}
+
+// Grow increases the capacity of the list, if necessary, so that another n elements can be
+// added without reallocating.
+func (l *ArrayList[T]) Grow(n int) {
+	if n > 0 {
+		l.values = slices.Grow(l.values, n)
+	}
+}
+
+// TrimToSize releases any capacity beyond the current number of elements.
+func (l *ArrayList[T]) TrimToSize() {
+	if cap(l.values) > len(l.values) {
+		values := make([]T, len(l.values))
+		copy(values, l.values)
+		l.values = values
+	}
+}
+
+// newArrayList initializes a slice backed list with the supplied capacity and options.
+func newArrayList[T any](capacity int, opts []Option[T]) *ArrayList[T] {
+	l := &ArrayList[T]{equality: newOptions(opts).equality}
+	if capacity > 0 {
+		l.values = make([]T, 0, capacity)
+	}
+	return l
+}
+
+// NewArrayCollectionOf initializes a collection backed by a slice.
+func NewArrayCollectionOf[T any](options ...Option[T]) CollectionOf[T] {
+	return newArrayList(0, options)
+}
+
+// NewArrayListOf initializes a list backed by a slice.
+func NewArrayListOf[T any](options ...Option[T]) ListOf[T] {
+	return newArrayList(0, options)
+}
+
+// NewArrayListOfCapacity initializes a list backed by a slice that can hold the specified
+// number of elements without reallocating.
+func NewArrayListOfCapacity[T any](capacity int, options ...Option[T]) *ArrayList[T] {
+	return newArrayList(capacity, options)
+}
+
+// NewArrayStackOf initializes a stack backed by a slice.
+func NewArrayStackOf[T any](options ...Option[T]) StackOf[T] {
+	return newArrayList(0, options)
+}
@@ -0,0 +1,91 @@
+package gollections_test
+
+import (
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the ArrayList as an implementation of Collection.
+func TestArrayCollection(t *testing.T) {
+	testCollection(t, gollections.NewArrayCollectionOf[any]())
+}
+
+// TestArrayCollectionSliceCopy tests the slice copy function for the ArrayList implementation of
+// Collection.
+func TestArrayCollectionSliceCopy(t *testing.T) {
+	testCollectionSliceCopy(t, gollections.NewArrayListOf[any]())
+}
+
+// Test the ArrayList as an implementation of List.
+func TestArrayList(t *testing.T) {
+	testList(t, gollections.NewArrayListOf[any]())
+}
+
+// Test the ArrayList as an implementation of Queue.
+func TestArrayListQueue(t *testing.T) {
+	testQueue(t, &gollections.ArrayList[any]{})
+}
+
+// Test the ArrayList as an implementation of Stack.
+func TestArrayStack(t *testing.T) {
+	testStack(t, gollections.NewArrayStackOf[any]())
+}
+
+// Test the ArrayList as an implementation of Deque.
+func TestArrayListDeque(t *testing.T) {
+	testDeque(t, &gollections.ArrayList[any]{})
+}
+
+// TestArrayListTyped tests the ArrayList with a concrete element type.
+func TestArrayListTyped(t *testing.T) {
+	testListTyped(t, gollections.NewArrayListOf[string]())
+}
+
+// TestArrayListDequeEmptied tests that an ArrayList emptied from either end holds no stale
+// elements.
+func TestArrayListDequeEmptied(t *testing.T) {
+	testDequeEmptied(t, &gollections.ArrayList[int]{})
+}
+
+// TestArrayListCapacity tests the capacity controls of the ArrayList.
+func TestArrayListCapacity(t *testing.T) {
+	list := gollections.NewArrayListOfCapacity[int](4)
+	if c := list.Cap(); c != 4 {
+		t.Fatalf("expected capacity 4, got %d", c)
+	}
+	list.Add(1, 2)
+	list.Grow(10)
+	if c := list.Cap(); c < 12 {
+		t.Fatalf("expected capacity of at least 12, got %d", c)
+	}
+	if size := list.Size(); size != 2 {
+		t.Fatalf("expected size 2, got %d", size)
+	}
+	list.TrimToSize()
+	if c := list.Cap(); c != 2 {
+		t.Fatalf("expected capacity 2, got %d", c)
+	}
+	if got, err := list.Get(1); err != nil || got != 2 {
+		t.Fatalf("expected 2, got %d, err: %v", got, err)
+	}
+	list.Clear()
+	if c := list.Cap(); c != 2 {
+		t.Fatalf("expected clear to retain capacity 2, got %d", c)
+	}
+}
+
+// TestArrayListIterator tests the iterators of the ArrayList.
+func TestArrayListIterator(t *testing.T) {
+	testListIterator(t, gollections.NewArrayListOf[int]())
+}
+
+// TestArrayListBulk tests the bulk operations of the ArrayList.
+func TestArrayListBulk(t *testing.T) {
+	testListBulk(t, gollections.NewArrayListOf[int]())
+}
+
+// TestArrayListSubList tests sub list views of the ArrayList.
+func TestArrayListSubList(t *testing.T) {
+	testSubList(t, gollections.NewArrayListOf[int]())
+}
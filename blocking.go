@@ -0,0 +1,358 @@
+package gollections
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"time"
+)
+
+// blockingQueue guards a queue with a lock and wakes waiting goroutines whenever the queue
+// changes. Waiters read the changed channel while holding the lock and wait for it to be closed,
+// which lets them wait on a context at the same time.
+type blockingQueue[T any] struct {
+	synchronizedCollection[T]
+	queue    QueueOf[T]
+	capacity int
+	closed   bool
+	changed  chan struct{}
+}
+
+// emptyCollection initializes an empty blocking queue that guards an empty queue of the same kind.
+// The queue only has the same capacity if bounded is true.
+func (q *blockingQueue[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return NewBlockingQueueOf(q.empty(bounded).(QueueOf[T]), q.capacityIf(bounded))
+}
+
+// capacityIf gets the capacity of the queue if bounded is true, and otherwise no capacity.
+func (q *blockingQueue[T]) capacityIf(bounded bool) int {
+	if bounded {
+		return q.capacity
+	}
+	return 0
+}
+
+// signal wakes all waiting goroutines. The write lock must be held.
+func (q *blockingQueue[T]) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// full checks if the queue cannot hold the specified number of additional elements. The lock
+// must be held.
+func (q *blockingQueue[T]) full(n int) bool {
+	return q.capacity > 0 && q.queue.Size()+n > q.capacity
+}
+
+// await calls attempt while holding the write lock until it reports that the operation was
+// completed. Returns a closed error if the operation cannot complete because the queue is closed,
+// or the error of the context if it is done first.
+func (q *blockingQueue[T]) await(ctx context.Context, attempt func() bool) error {
+	for {
+		q.mutex.Lock()
+		if attempt() {
+			q.signal()
+			q.mutex.Unlock()
+			return nil
+		}
+		if q.closed {
+			q.mutex.Unlock()
+			return ErrClosed
+		}
+		changed := q.changed
+		q.mutex.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// put adds an element using the supplied function once there is space for it.
+func (q *blockingQueue[T]) put(ctx context.Context, add func(...T), value T) error {
+	return q.await(ctx, func() bool {
+		if q.closed || q.full(1) {
+			return false
+		}
+		add(value)
+		return true
+	})
+}
+
+// take removes an element using the supplied function once there is one.
+func (q *blockingQueue[T]) take(ctx context.Context, pop func() (T, error)) (T, error) {
+	var value T
+	err := q.await(ctx, func() bool {
+		if q.queue.IsEmpty() {
+			return false
+		}
+		var err error
+		value, err = pop()
+		return err == nil
+	})
+	return value, err
+}
+
+// withTimeout calls the supplied function with a context that is done after the timeout, and
+// converts the resulting deadline error to a timeout error.
+func withTimeout(timeout time.Duration, f func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := f(ctx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return ErrTimeout
+		}
+		return err
+	}
+	return nil
+}
+
+// add appends elements using the supplied function without waiting.
+// Panics if the queue is closed or does not have space for the elements.
+func (q *blockingQueue[T]) add(add func(...T), values []T) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.closed {
+		panic(ErrClosed)
+	}
+	if q.full(len(values)) {
+		panic(ErrCapacityExceeded)
+	}
+	add(values...)
+	q.signal()
+}
+
+// Add appends new elements to the end of the queue without waiting.
+// Panics if the queue is closed or does not have space for the elements.
+func (q *blockingQueue[T]) Add(values ...T) {
+	q.add(q.queue.Add, values)
+}
+
+// Clear removes all elements from the queue.
+func (q *blockingQueue[T]) Clear() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.queue.Clear()
+	q.signal()
+}
+
+// Remove removes all specified values from the queue.
+func (q *blockingQueue[T]) Remove(values ...T) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.queue.Remove(values...)
+	q.signal()
+}
+
+// PeekFirst gets the value of the first element in the queue.
+func (q *blockingQueue[T]) PeekFirst() (T, error) {
+	q.rlock()
+	defer q.runlock()
+	return q.queue.PeekFirst()
+}
+
+// PopFirst gets the value of the first element in the queue without waiting. The element is
+// removed.
+func (q *blockingQueue[T]) PopFirst() (T, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	value, err := q.queue.PopFirst()
+	if err == nil {
+		q.signal()
+	}
+	return value, err
+}
+
+// Close closes the queue. Goroutines waiting to add an element, or to remove an element from an
+// empty queue, return a closed error.
+func (q *blockingQueue[T]) Close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.signal()
+	}
+}
+
+// DrainTo removes at most max elements, or all elements if max is negative, and adds them to the
+// supplied collection. Returns the number of elements removed.
+func (q *blockingQueue[T]) DrainTo(c CollectionOf[T], max int) int {
+	q.mutex.Lock()
+	var values []T
+	for max < 0 || len(values) < max {
+		value, err := q.queue.PopFirst()
+		if err != nil {
+			break
+		}
+		values = append(values, value)
+	}
+	if len(values) > 0 {
+		q.signal()
+	}
+	q.mutex.Unlock()
+	// the collection is filled without holding the lock in case it is the queue itself
+	c.Add(values...)
+	return len(values)
+}
+
+// Offer adds an element to the end of the queue, waiting at most the supplied duration for space
+// to become available. Returns a timeout error if the queue is still full.
+func (q *blockingQueue[T]) Offer(value T, timeout time.Duration) error {
+	return withTimeout(timeout, func(ctx context.Context) error {
+		return q.PutContext(ctx, value)
+	})
+}
+
+// Poll removes the first element of the queue, waiting at most the supplied duration for an
+// element to become available. Returns a timeout error if the queue is still empty.
+func (q *blockingQueue[T]) Poll(timeout time.Duration) (T, error) {
+	var value T
+	err := withTimeout(timeout, func(ctx context.Context) error {
+		var err error
+		value, err = q.TakeContext(ctx)
+		return err
+	})
+	return value, err
+}
+
+// Put adds an element to the end of the queue, waiting for space to become available.
+func (q *blockingQueue[T]) Put(value T) error {
+	return q.PutContext(context.Background(), value)
+}
+
+// PutContext adds an element to the end of the queue, waiting for space to become available
+// until the context is done.
+func (q *blockingQueue[T]) PutContext(ctx context.Context, value T) error {
+	return q.put(ctx, q.queue.Add, value)
+}
+
+// Take removes the first element of the queue, waiting for an element to become available.
+func (q *blockingQueue[T]) Take() (T, error) {
+	return q.TakeContext(context.Background())
+}
+
+// TakeContext removes the first element of the queue, waiting for an element to become available
+// until the context is done.
+func (q *blockingQueue[T]) TakeContext(ctx context.Context) (T, error) {
+	return q.take(ctx, q.queue.PopFirst)
+}
+
+// blockingDeque is a blocking queue that can be accessed at both ends.
+type blockingDeque[T any] struct {
+	blockingQueue[T]
+	deque DequeOf[T]
+}
+
+// emptyCollection initializes an empty blocking deque that guards an empty deque of the same kind.
+// The deque only has the same capacity if bounded is true.
+func (q *blockingDeque[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return NewBlockingDequeOf(q.empty(bounded).(DequeOf[T]), q.capacityIf(bounded))
+}
+
+// AddFirst adds new elements to the beginning of the deque without waiting.
+// Panics if the deque is closed or does not have space for the elements.
+func (d *blockingDeque[T]) AddFirst(values ...T) {
+	d.add(d.deque.AddFirst, values)
+}
+
+// Backward gets a sequence over a snapshot of the elements of the deque from last to first.
+func (d *blockingDeque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		values := d.ToArray()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// PeekLast gets the value of the last element in the deque.
+func (d *blockingDeque[T]) PeekLast() (T, error) {
+	d.rlock()
+	defer d.runlock()
+	return d.deque.PeekLast()
+}
+
+// PopLast gets the value of the last element in the deque without waiting. The element is
+// removed.
+func (d *blockingDeque[T]) PopLast() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	value, err := d.deque.PopLast()
+	if err == nil {
+		d.signal()
+	}
+	return value, err
+}
+
+// OfferFirst adds an element to the beginning of the deque, waiting at most the supplied duration
+// for space to become available. Returns a timeout error if the deque is still full.
+func (d *blockingDeque[T]) OfferFirst(value T, timeout time.Duration) error {
+	return withTimeout(timeout, func(ctx context.Context) error {
+		return d.PutFirstContext(ctx, value)
+	})
+}
+
+// PollLast removes the last element of the deque, waiting at most the supplied duration for an
+// element to become available. Returns a timeout error if the deque is still empty.
+func (d *blockingDeque[T]) PollLast(timeout time.Duration) (T, error) {
+	var value T
+	err := withTimeout(timeout, func(ctx context.Context) error {
+		var err error
+		value, err = d.TakeLastContext(ctx)
+		return err
+	})
+	return value, err
+}
+
+// PutFirst adds an element to the beginning of the deque, waiting for space to become available.
+func (d *blockingDeque[T]) PutFirst(value T) error {
+	return d.PutFirstContext(context.Background(), value)
+}
+
+// PutFirstContext adds an element to the beginning of the deque, waiting for space to become
+// available until the context is done.
+func (d *blockingDeque[T]) PutFirstContext(ctx context.Context, value T) error {
+	return d.put(ctx, d.deque.AddFirst, value)
+}
+
+// TakeLast removes the last element of the deque, waiting for an element to become available.
+func (d *blockingDeque[T]) TakeLast() (T, error) {
+	return d.TakeLastContext(context.Background())
+}
+
+// TakeLastContext removes the last element of the deque, waiting for an element to become
+// available until the context is done.
+func (d *blockingDeque[T]) TakeLastContext(ctx context.Context) (T, error) {
+	return d.take(ctx, d.deque.PopLast)
+}
+
+// newBlockingQueue initializes a blocking queue around the supplied queue.
+func newBlockingQueue[T any](queue QueueOf[T], capacity int) blockingQueue[T] {
+	return blockingQueue[T]{
+		synchronizedCollection: newSynchronizedCollection[T](queue, &sync.RWMutex{}),
+		queue:                  queue,
+		capacity:               capacity,
+		changed:                make(chan struct{}),
+	}
+}
+
+// NewBlockingQueueOf initializes a blocking queue that stores its elements in the supplied queue,
+// e.g. one created by NewLinkedQueueOf, and holds at most the specified number of elements. A
+// capacity less than one makes the queue unbounded. The supplied queue must not be accessed other
+// than through the returned queue.
+func NewBlockingQueueOf[T any](queue QueueOf[T], capacity int) BlockingQueueOf[T] {
+	q := newBlockingQueue(queue, capacity)
+	return &q
+}
+
+// NewBlockingDequeOf initializes a blocking deque that stores its elements in the supplied deque,
+// e.g. one created by NewLinkedDequeOf, and holds at most the specified number of elements. A
+// capacity less than one makes the deque unbounded. The supplied deque must not be accessed other
+// than through the returned deque.
+func NewBlockingDequeOf[T any](deque DequeOf[T], capacity int) BlockingDequeOf[T] {
+	return &blockingDeque[T]{blockingQueue: newBlockingQueue[T](deque, capacity), deque: deque}
+}
@@ -0,0 +1,233 @@
+package gollections_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the blocking queue and deque as implementations of Queue and Deque.
+func TestBlockingQueueInterfaces(t *testing.T) {
+	testQueue(t, gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[any](), 0))
+	testDeque(t, gollections.NewBlockingDequeOf(gollections.NewArrayDequeOf[any](), 0))
+}
+
+// TestBlockingQueue tests that a bounded queue blocks producers while full and consumers while
+// empty.
+func TestBlockingQueue(t *testing.T) {
+	queue := gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[int](), 2)
+	if err := queue.Put(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Offer(2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Offer(3, 10*time.Millisecond); err != gollections.ErrTimeout {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	put := make(chan error)
+	go func() {
+		put <- queue.Put(3)
+	}()
+	select {
+	case err := <-put:
+		t.Fatalf("expected put to block, got %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	if value, err := queue.Take(); err != nil || value != 1 {
+		t.Fatalf("expected 1, got %d, err: %v", value, err)
+	}
+	if err := <-put; err != nil {
+		t.Fatal(err)
+	}
+	if expected, got := []int{2, 3}, queue.ToArray(); !slices.Equal(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != gollections.ErrCapacityExceeded {
+				t.Fatalf("expected capacity exceeded panic, got %v", r)
+			}
+		}()
+		queue.Add(4)
+	}()
+	queue.Clear()
+	if _, err := queue.Poll(10 * time.Millisecond); err != gollections.ErrTimeout {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	taken := make(chan int)
+	go func() {
+		value, err := queue.Take()
+		if err != nil {
+			t.Error(err)
+		}
+		taken <- value
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Add(5)
+	if value := <-taken; value != 5 {
+		t.Fatalf("expected 5, got %d", value)
+	}
+}
+
+// TestBlockingQueueContext tests that blocking calls return when their context is done.
+func TestBlockingQueueContext(t *testing.T) {
+	queue := gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[int](), 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := queue.TakeContext(ctx)
+		done <- err
+	}()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+	queue.Add(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.PutContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got %v", err)
+	}
+}
+
+// TestBlockingQueueClose tests that closing a queue wakes waiting goroutines and that the
+// remaining elements can still be taken.
+func TestBlockingQueueClose(t *testing.T) {
+	queue := gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[int](), 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := queue.Take(); err != gollections.ErrClosed {
+			t.Errorf("expected closed error, got %v", err)
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	wg.Wait()
+
+	queue = gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[int](), 1)
+	queue.Add(1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := queue.Put(2); err != gollections.ErrClosed {
+			t.Errorf("expected closed error, got %v", err)
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	wg.Wait()
+	if value, err := queue.Take(); err != nil || value != 1 {
+		t.Fatalf("expected 1, got %d, err: %v", value, err)
+	}
+	if _, err := queue.Take(); err != gollections.ErrClosed {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+}
+
+// TestBlockingQueueDrainTo tests draining elements from a queue into another collection.
+func TestBlockingQueueDrainTo(t *testing.T) {
+	queue := gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[int](), 0)
+	queue.Add(1, 2, 3, 4, 5)
+	list := gollections.NewArrayListOf[int]()
+	if n := queue.DrainTo(list, 2); n != 2 {
+		t.Fatalf("expected 2 elements drained, got %d", n)
+	}
+	expectList(t, list, 1, 2)
+	if n := queue.DrainTo(list, -1); n != 3 {
+		t.Fatalf("expected 3 elements drained, got %d", n)
+	}
+	expectList(t, list, 1, 2, 3, 4, 5)
+	if !queue.IsEmpty() {
+		t.Fatalf("expected empty queue, got %v", queue.ToArray())
+	}
+	if n := queue.DrainTo(list, -1); n != 0 {
+		t.Fatalf("expected no elements drained, got %d", n)
+	}
+}
+
+// TestBlockingDeque tests the blocking operations at the end of a deque.
+func TestBlockingDeque(t *testing.T) {
+	deque := gollections.NewBlockingDequeOf(gollections.NewLinkedDequeOf[int](), 2)
+	if err := deque.PutFirst(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := deque.OfferFirst(2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := deque.OfferFirst(3, 10*time.Millisecond); err != gollections.ErrTimeout {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if value, err := deque.TakeLast(); err != nil || value != 1 {
+		t.Fatalf("expected 1, got %d, err: %v", value, err)
+	}
+	if value, err := deque.PollLast(0); err != nil || value != 2 {
+		t.Fatalf("expected 2, got %d, err: %v", value, err)
+	}
+	if _, err := deque.PollLast(10 * time.Millisecond); err != gollections.ErrTimeout {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+// TestBlockingQueuePipeline tests a producer/consumer pipeline over a bounded queue.
+func TestBlockingQueuePipeline(t *testing.T) {
+	queue := gollections.NewBlockingQueueOf(gollections.NewLinkedQueueOf[int](), 4)
+	const producers, consumers, count = 4, 4, 500
+	var producing, consuming sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func(p int) {
+			defer producing.Done()
+			for i := 0; i < count; i++ {
+				if err := queue.Put(p*count + i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+	results := make(chan int, producers*count)
+	for c := 0; c < consumers; c++ {
+		consuming.Add(1)
+		go func() {
+			defer consuming.Done()
+			for {
+				value, err := queue.Take()
+				if err == gollections.ErrClosed {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if queue.Size() > 4 {
+					t.Errorf("expected at most 4 elements, got %d", queue.Size())
+				}
+				results <- value
+			}
+		}()
+	}
+	producing.Wait()
+	queue.Close()
+	consuming.Wait()
+	close(results)
+	seen := make([]bool, producers*count)
+	for value := range results {
+		if seen[value] {
+			t.Fatalf("value %d was taken twice", value)
+		}
+		seen[value] = true
+	}
+	for value, ok := range seen {
+		if !ok {
+			t.Fatalf("value %d was not taken", value)
+		}
+	}
+}
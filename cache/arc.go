@@ -0,0 +1,142 @@
+package cache
+
+// Segments of the ARC policy.
+const (
+	arcRecent uint8 = iota
+	arcFrequent
+	arcRecentGhost
+	arcFrequentGhost
+)
+
+// arcPolicy is the adaptive replacement policy of Megiddo and Modha. Keys used once are held in
+// a recent list and keys used again in a frequent list. Keys evicted from either list are
+// remembered in a ghost list, and adding a key remembered by a ghost list shifts the target size
+// of the recent list towards the list that would have kept it.
+type arcPolicy[K comparable] struct {
+	nodes    map[K]*node[K]
+	lists    [4]keyList[K]
+	target   int
+	capacity int
+	// frequentHit records that the last key added was remembered by the frequent ghost list
+	frequentHit bool
+	// added is the node of the key added last if it is new, which ARC does not count as held by
+	// the recent list when selecting a list to evict from
+	added *node[K]
+}
+
+// move unlinks a node from its list and links it to the end of another list.
+func (p *arcPolicy[K]) move(n *node[K], segment uint8) {
+	p.lists[n.segment].remove(n)
+	n.segment = segment
+	p.lists[segment].pushBack(n)
+}
+
+// forget drops the least recently used key of a ghost list.
+func (p *arcPolicy[K]) forget(segment uint8) {
+	if n := p.lists[segment].popFront(); n != nil {
+		delete(p.nodes, n.key)
+	}
+}
+
+// trim limits the ghost lists so that the recent and frequent lists with their ghosts hold at
+// most twice the capacity, and the recent list with its ghost at most the capacity.
+func (p *arcPolicy[K]) trim() {
+	if p.capacity <= 0 {
+		return
+	}
+	for p.lists[arcRecent].length+p.lists[arcRecentGhost].length > p.capacity &&
+		p.lists[arcRecentGhost].length > 0 {
+		p.forget(arcRecentGhost)
+	}
+	for len(p.nodes) > 2*p.capacity && p.lists[arcFrequentGhost].length > 0 {
+		p.forget(arcFrequentGhost)
+	}
+}
+
+func (p *arcPolicy[K]) Access(key K) {
+	if n, ok := p.nodes[key]; ok && (n.segment == arcRecent || n.segment == arcFrequent) {
+		p.move(n, arcFrequent)
+	}
+}
+
+func (p *arcPolicy[K]) Add(key K) {
+	p.frequentHit = false
+	p.added = nil
+	n, ok := p.nodes[key]
+	if !ok {
+		n = &node[K]{key: key, segment: arcRecent}
+		p.nodes[key] = n
+		p.lists[arcRecent].pushBack(n)
+		p.added = n
+		p.trim()
+		return
+	}
+	recentGhosts, frequentGhosts := p.lists[arcRecentGhost].length, p.lists[arcFrequentGhost].length
+	switch n.segment {
+	case arcRecentGhost:
+		p.target = min(p.target+max(frequentGhosts/recentGhosts, 1), p.capacity)
+	case arcFrequentGhost:
+		p.target = max(p.target-max(recentGhosts/frequentGhosts, 1), 0)
+		p.frequentHit = true
+	}
+	p.move(n, arcFrequent)
+}
+
+func (p *arcPolicy[K]) Clear() {
+	p.nodes = map[K]*node[K]{}
+	for i := range p.lists {
+		p.lists[i].clear()
+	}
+	p.target = 0
+	p.frequentHit = false
+	p.added = nil
+}
+
+func (p *arcPolicy[K]) Evict() (K, bool) {
+	recent, frequent := &p.lists[arcRecent], &p.lists[arcFrequent]
+	held := recent.length
+	if p.added != nil && p.added.segment == arcRecent {
+		held--
+	}
+	var n *node[K]
+	switch {
+	case recent.length > 0 && (held > p.target || (p.frequentHit && held == p.target) ||
+		frequent.length == 0):
+		n = recent.front()
+		p.move(n, arcRecentGhost)
+	case frequent.length > 0:
+		n = frequent.front()
+		p.move(n, arcFrequentGhost)
+	default:
+		var zero K
+		return zero, false
+	}
+	p.trim()
+	return n.key, true
+}
+
+func (p *arcPolicy[K]) Remove(key K) {
+	if n, ok := p.nodes[key]; ok && (n.segment == arcRecent || n.segment == arcFrequent) {
+		p.lists[n.segment].remove(n)
+		delete(p.nodes, key)
+		if n == p.added {
+			p.added = nil
+		}
+	}
+}
+
+func (p *arcPolicy[K]) SetCapacity(capacity int) {
+	p.capacity = capacity
+	p.target = min(p.target, max(capacity, 0))
+	p.trim()
+}
+
+// NewARCPolicy initializes an adaptive replacement policy, which balances evicting the least
+// recently used key against evicting the least frequently used key according to which of the
+// two would have kept recently evicted keys. The keys of as many entries as the cache holds are
+// remembered after they are evicted.
+func NewARCPolicy[K comparable]() EvictionPolicy[K] {
+	p := &arcPolicy[K]{}
+	p.Clear()
+	return p
+}
@@ -2,154 +2,965 @@
 package cache
 
 import (
+	"cmp"
+	"hash/maphash"
+	"iter"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bsladewski/gollections"
 )
 
-// A Cache represents a key/value store.
-type Cache interface {
+// A CacheOf represents a key/value store.
+//
+// The compound operations, such as PutIfAbsent and Compute, read and change the entry of a key
+// atomically: no other change to the cache happens in between. The functions they are supplied
+// are called while the cache is locked, so they must be quick and must not use the cache. Entries
+// added or updated by compound operations expire after the default time to live of the cache, if
+// any, and are rejected with ErrTooHeavy, leaving the cache unchanged, if they weigh more than
+// the maximum weight of the cache.
+type CacheOf[K comparable, V any] interface {
 	// Clear removes all entries from the cache.
 	Clear()
+	// Close stops the janitor of the cache and the goroutine of an asynchronous listener, if any,
+	// once the listener has been told about all removed entries. The cache can still be used after
+	// it is closed, and then tells the listener about removed entries directly.
+	Close()
+	// Compute sets the value of a key to the value returned by the supplied function, which is
+	// called with the current value and whether the cache holds one. The entry is removed if the
+	// function returns false. Returns the new value and whether the cache holds it.
+	Compute(key K, remap func(key K, value V, ok bool) (V, bool)) (V, bool, error)
+	// ComputeIfAbsent retrieves a value from the cache, computing it with the supplied function and
+	// adding it to the cache if it is missing. Nothing is added if the function returns an error,
+	// which is returned.
+	ComputeIfAbsent(key K, compute func(key K) (V, error)) (V, error)
+	// ComputeIfPresent sets the value of a key held by the cache to the value returned by the
+	// supplied function, which is called with the current value. The entry is removed if the
+	// function returns false. Returns the new value and whether the cache holds it.
+	ComputeIfPresent(key K, remap func(key K, value V) (V, bool)) (V, bool, error)
+	// Contains reports whether the cache holds an unexpired entry for a key, without recording that
+	// the entry was used.
+	Contains(key K) bool
+	// Entries iterates over a snapshot of the unexpired entries of the cache, from least to most
+	// recently used.
+	Entries() iter.Seq2[K, V]
 	// Get retrieves a value from the cache. Returns an error if no such entry exists.
-	Get(key interface{}) (interface{}, error)
-	// Put adds or updates an entry in the cache.
-	Put(key interface{}, value interface{})
+	Get(key K) (V, error)
+	// GetIfPresent retrieves a value from the cache without loading it if it is missing. It is the
+	// same as Get unless the cache is a LoadingCacheOf, whose Get loads missing values. Returns
+	// gollections.ErrNoSuchElement if no such entry exists.
+	GetIfPresent(key K) (V, error)
+	// Keys iterates over a snapshot of the keys of the unexpired entries of the cache, from least
+	// to most recently used.
+	Keys() iter.Seq[K]
+	// Merge adds an entry to the cache if it does not hold the key, or otherwise sets the value of
+	// the key to the result of merging the current value with the supplied value. The entry is
+	// removed if the merge function returns false. Returns the new value and whether the cache
+	// holds it.
+	Merge(key K, value V, merge func(old, value V) (V, bool)) (V, bool, error)
+	// Peek retrieves a value from the cache without recording that the entry was used, so that
+	// the entry is not retained by the eviction policy and the stats are unchanged. Returns an
+	// error if no such entry exists.
+	Peek(key K) (V, error)
+	// Put adds or updates an entry in the cache. The entry expires after the default time to live
+	// of the cache, if any. Returns ErrTooHeavy, and leaves the cache unchanged, if the entry weighs
+	// more than the maximum weight of the cache.
+	Put(key K, value V) error
+	// PutWithTTL adds or updates an entry in the cache that expires after the supplied time to
+	// live. An entry with a time to live less than or equal to zero never expires. Returns
+	// ErrTooHeavy, and leaves the cache unchanged, if the entry weighs more than the maximum
+	// weight of the cache.
+	PutWithTTL(key K, value V, ttl time.Duration) error
+	// PutIfAbsent adds an entry to the cache unless it holds the key. Returns the value held for
+	// the key and true if there is one, or otherwise the supplied value and false.
+	PutIfAbsent(key K, value V) (V, bool, error)
+	// Replace updates the value of a key only if the cache holds the key. Returns the replaced value
+	// and whether there was one.
+	Replace(key K, value V) (V, bool, error)
+	// Resize updates the maximum number of entries allowed in the cache, and returns the entries
+	// evicted to fit in the new size in the order they were evicted.
+	Resize(maxSize int) []gollections.Pair[K, V]
 	// SetMaxSize updates the maximum number of entries allows in the cache.
 	SetMaxSize(maxSize int)
-	// Size gets the current number of entries in the cache.
+	// SetMaxWeight updates the maximum total weight of the entries in the cache, see WithWeigher.
+	// A maximum weight less than or equal to zero means the weight of the cache is not limited.
+	SetMaxWeight(maxWeight int64)
+	// Size gets the current number of entries in the cache, which may include expired entries that
+	// have not yet been removed.
 	Size() int
+	// Weight gets the total weight of the entries in the cache, which is the number of entries
+	// unless the cache was initialized using WithWeigher.
+	Weight() int64
+	// Stats gets a snapshot of how the cache has been used. The snapshot is empty unless the cache
+	// was initialized using WithStats.
+	Stats() Stats
 	// Remove deletes a single entry from the cache.
-	Remove(key interface{})
+	Remove(key K)
+	// Values iterates over a snapshot of the values of the unexpired entries of the cache, from
+	// least to most recently used.
+	Values() iter.Seq[V]
 }
 
-// A cache provides access to key/value pairs.
-type cache struct {
-	maxSize int
-	values  map[interface{}]interface{}
-	keys    gollections.List
+// A keySet maps keys to an equal key already held by a cache, so that keys that are equal by a key
+// equality strategy refer to the same entry. A nil keySet leaves keys unchanged.
+type keySet[K comparable] struct {
+	equality gollections.Hasher[K]
+	buckets  map[uint64][]K
 }
 
-// prune removes elements from the head of the cache value list.
-// As elements are added to the tail when added or accessed, the head will always contain the
-// oldest entry in the cache.
-func (c *cache) prune() error {
-	if c.maxSize <= 0 {
+// newKeySet initializes a key set for the supplied strategy, or nil if there is no strategy.
+func newKeySet[K comparable](equality gollections.Hasher[K]) *keySet[K] {
+	if equality == nil {
 		return nil
 	}
-	for c.keys.Size() > c.maxSize {
-		key, err := c.keys.Get(0)
-		if err != nil {
-			return err
+	return &keySet[K]{equality: equality, buckets: map[uint64][]K{}}
+}
+
+// canonical gets the held key that is equal to the supplied key. If no such key is held, the
+// supplied key is added to the set when add is true.
+func (s *keySet[K]) canonical(key K, add bool) K {
+	if s == nil {
+		return key
+	}
+	hash := s.equality.Hash(key)
+	for _, k := range s.buckets[hash] {
+		if s.equality.Equal(k, key) {
+			return k
 		}
-		c.keys.RemoveAt(0)
-		delete(c.values, key)
 	}
-	return nil
+	if add {
+		s.buckets[hash] = append(s.buckets[hash], key)
+	}
+	return key
 }
 
-// touch moves an existing node to the tail of the key list.
-func (c *cache) touch(key interface{}) {
-	c.keys.Remove(key)
-	c.keys.Add(key)
+// remove deletes a held key from the set.
+func (s *keySet[K]) remove(key K) {
+	if s == nil {
+		return
+	}
+	hash := s.equality.Hash(key)
+	bucket := s.buckets[hash]
+	for i, k := range bucket {
+		if k == key {
+			bucket[i] = bucket[len(bucket)-1]
+			bucket = bucket[:len(bucket)-1]
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(s.buckets, hash)
+	} else {
+		s.buckets[hash] = bucket
+	}
 }
 
-func (c *cache) Clear() {
-	c.keys.Clear()
-	c.values = map[interface{}]interface{}{}
+// clear deletes all keys from the set.
+func (s *keySet[K]) clear() {
+	if s != nil {
+		s.buckets = map[uint64][]K{}
+	}
 }
 
-func (c *cache) Get(key interface{}) (interface{}, error) {
-	if value, ok := c.values[key]; ok {
-		c.touch(key)
-		return value, nil
+// An entry holds a value of a cache, its weight and the time it expires. An entry with a zero
+// expiry time never expires.
+type entry[V any] struct {
+	value   V
+	weight  int64
+	expires time.Time
+}
+
+// expired reports whether the entry has expired by the time of the supplied clock.
+func (e entry[V]) expired(clock Clock) bool {
+	return !e.expires.IsZero() && !clock.Now().Before(e.expires)
+}
+
+// causeOf gets the cause of removing the entry for the supplied reason, which is CauseExpired
+// instead if the entry has expired.
+func (e entry[V]) causeOf(cause RemovalCause, clock Clock) RemovalCause {
+	if e.expired(clock) {
+		return CauseExpired
 	}
-	return nil, gollections.ErrNoSuchElement
+	return cause
 }
 
-func (c *cache) Put(key interface{}, value interface{}) {
-	if _, ok := c.values[key]; ok {
-		c.touch(key)
-	} else {
-		c.keys.Add(key)
+// newEntry initializes an entry that expires after the supplied time to live, or never if the time
+// to live is less than or equal to zero.
+func newEntry[V any](value V, weight int64, ttl time.Duration, clock Clock) entry[V] {
+	e := entry[V]{value: value, weight: weight}
+	if ttl > 0 {
+		e.expires = clock.Now().Add(ttl)
+	}
+	return e
+}
+
+// A store holds the entries of a cache, keyed by the canonical form of their keys.
+type store[K comparable, V any] struct {
+	entries   map[K]entry[V]
+	canonical *keySet[K]
+}
+
+// newStore initializes an empty store that canonicalizes keys using the supplied strategy.
+func newStore[K comparable, V any](equality gollections.Hasher[K]) store[K, V] {
+	return store[K, V]{entries: map[K]entry[V]{}, canonical: newKeySet(equality)}
+}
+
+// lookup gets the canonical form of a key and its entry. Returns false if no entry is held.
+func (s *store[K, V]) lookup(key K) (K, entry[V], bool) {
+	key = s.canonical.canonical(key, false)
+	e, ok := s.entries[key]
+	return key, e, ok
+}
+
+// put adds or updates an entry. Returns the canonical form of the key, and the entry it replaced
+// if there was one.
+func (s *store[K, V]) put(key K, e entry[V]) (K, entry[V], bool) {
+	key = s.canonical.canonical(key, true)
+	old, ok := s.entries[key]
+	s.entries[key] = e
+	return key, old, ok
+}
+
+// remove deletes the entry of a key in canonical form and returns it.
+func (s *store[K, V]) remove(key K) entry[V] {
+	e := s.entries[key]
+	delete(s.entries, key)
+	s.canonical.remove(key)
+	return e
+}
+
+// expired gets the keys of the entries that have expired.
+func (s *store[K, V]) expired(clock Clock) []K {
+	var keys []K
+	for key, e := range s.entries {
+		if e.expired(clock) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// clear deletes all entries.
+func (s *store[K, V]) clear() {
+	s.entries = map[K]entry[V]{}
+	s.canonical.clear()
+}
+
+// A cache provides access to key/value pairs. The eviction policy is told of every change to the
+// entries and selects the entries to evict when the cache holds too many. Expired entries are
+// removed when they are read.
+type cache[K comparable, V any] struct {
+	compound[K, V]
+	limits[K]
+	store    store[K, V]
+	weigher  Weigher[K, V]
+	weight   int64
+	ttl      time.Duration
+	clock    Clock
+	notifier *notifier[K, V]
+	stats    *statsCounter
+	recorder StatsRecorder
+}
+
+// prune evicts entries selected by the policy until the cache holds no more than the maximum
+// number and weight of entries. The evicted entries are appended to evicted unless it is nil.
+func (c *cache[K, V]) prune(evicted *[]gollections.Pair[K, V]) {
+	var removals []removal[K, V]
+	full := false
+	for c.exceeded(len(c.store.entries), c.weight) {
+		key, ok := c.policy.Evict()
+		if !ok {
+			break
+		}
+		e := c.store.remove(key)
+		c.weight -= e.weight
+		if evicted != nil {
+			*evicted = append(*evicted, gollections.Pair[K, V]{First: key, Second: e.value})
+		}
+		removals = c.notifier.add(removals, key, e.value, e.causeOf(CauseSize, c.clock))
+		full = true
+	}
+	c.resize(len(c.store.entries), full)
+	c.notifier.notify(removals)
+}
+
+// remove deletes the entry of a key in canonical form.
+func (c *cache[K, V]) remove(key K, cause RemovalCause) {
+	e := c.store.remove(key)
+	c.weight -= e.weight
+	c.policy.Remove(key)
+	c.notifier.notify(c.notifier.add(nil, key, e.value, e.causeOf(cause, c.clock)))
+}
+
+func (c *cache[K, V]) Clear() {
+	var removals []removal[K, V]
+	for key, e := range c.store.entries {
+		removals = c.notifier.add(removals, key, e.value, CauseCleared)
+	}
+	c.store.clear()
+	c.weight = 0
+	c.policy.Clear()
+	c.notifier.notify(removals)
+}
+
+func (c *cache[K, V]) Close() {
+	c.notifier.close()
+}
+
+// compute applies a computation to the entry of a key.
+func (c *cache[K, V]) compute(key K, computation computation[V]) (V, bool, error) {
+	key, e, ok := c.store.lookup(key)
+	live := ok && !e.expired(c.clock)
+	var zero V
+	if !live {
+		e.value = zero
+	}
+	value, outcome, err := computation(e.value, live)
+	if err != nil {
+		return zero, false, err
+	}
+	switch outcome {
+	case keep:
+		if live {
+			c.policy.Access(key)
+		} else if ok {
+			c.remove(key, CauseExpired)
+		}
+		return e.value, live, nil
+	case discard:
+		if ok {
+			c.remove(key, CauseExplicit)
+		}
+		return zero, false, nil
+	}
+	if err := c.PutWithTTL(key, value, c.ttl); err != nil {
+		return zero, false, err
 	}
-	c.values[key] = value
-	c.prune()
+	return value, true, nil
 }
 
-func (c *cache) SetMaxSize(maxSize int) {
+func (c *cache[K, V]) Contains(key K) bool {
+	_, e, ok := c.store.lookup(key)
+	return ok && !e.expired(c.clock)
+}
+
+func (c *cache[K, V]) Get(key K) (V, error) {
+	key, e, ok := c.store.lookup(key)
+	if !ok || e.expired(c.clock) {
+		if ok {
+			c.remove(key, CauseExpired)
+		}
+		if c.recorder != nil {
+			c.recorder.RecordMiss()
+		}
+		var zero V
+		return zero, gollections.ErrNoSuchElement
+	}
+	if c.recorder != nil {
+		c.recorder.RecordHit()
+	}
+	c.policy.Access(key)
+	return e.value, nil
+}
+
+func (c *cache[K, V]) GetIfPresent(key K) (V, error) {
+	return c.Get(key)
+}
+
+func (c *cache[K, V]) Put(key K, value V) error {
+	return c.PutWithTTL(key, value, c.ttl)
+}
+
+func (c *cache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	weight := weigh(c.weigher, key, value)
+	if err := c.admit(weight); err != nil {
+		return err
+	}
+	key, old, replaced := c.store.put(key, newEntry(value, weight, ttl, c.clock))
+	c.weight += weight - old.weight
+	switch {
+	case !replaced:
+		c.policy.Add(key)
+	case old.expired(c.clock):
+		// an expired entry is replaced by a new entry rather than updated
+		c.policy.Remove(key)
+		c.policy.Add(key)
+	default:
+		c.policy.Access(key)
+	}
+	if replaced {
+		c.notifier.notify(c.notifier.add(nil, key, old.value, old.causeOf(CauseReplaced, c.clock)))
+	}
+	c.prune(nil)
+	return nil
+}
+
+func (c *cache[K, V]) Peek(key K) (V, error) {
+	_, e, ok := c.store.lookup(key)
+	if !ok || e.expired(c.clock) {
+		var zero V
+		return zero, gollections.ErrNoSuchElement
+	}
+	return e.value, nil
+}
+
+func (c *cache[K, V]) Resize(maxSize int) []gollections.Pair[K, V] {
 	c.maxSize = maxSize
-	c.prune()
+	c.resize(len(c.store.entries), false)
+	var evicted []gollections.Pair[K, V]
+	c.prune(&evicted)
+	return evicted
+}
+
+func (c *cache[K, V]) SetMaxSize(maxSize int) {
+	c.Resize(maxSize)
+}
+
+func (c *cache[K, V]) SetMaxWeight(maxWeight int64) {
+	c.maxWeight = maxWeight
+	c.resize(len(c.store.entries), false)
+	c.prune(nil)
 }
 
-func (c *cache) Size() int {
-	return c.keys.Size()
+func (c *cache[K, V]) Size() int {
+	return len(c.store.entries)
 }
 
-func (c *cache) Remove(key interface{}) {
-	c.keys.Remove(key)
-	delete(c.values, key)
+func (c *cache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
 }
 
-// A concurrentCache synchronizes a standard cache using a read/write mutex.
-type concurrentCache struct {
-	cache
-	mutex *sync.RWMutex
+func (c *cache[K, V]) Weight() int64 {
+	return c.weight
 }
 
-func (c *concurrentCache) Clear() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.cache.Clear()
+func (c *cache[K, V]) Remove(key K) {
+	if key, _, ok := c.store.lookup(key); ok {
+		c.remove(key, CauseExplicit)
+	}
 }
 
-func (c *concurrentCache) Get(key interface{}) (interface{}, error) {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-	return c.cache.Get(key)
+// snapshot gets the unexpired entries of the cache from least to most recently used.
+func (c *cache[K, V]) snapshot() []gollections.Pair[K, V] {
+	var entries []gollections.Pair[K, V]
+	for _, key := range c.recency.keys() {
+		if e := c.store.entries[key]; !e.expired(c.clock) {
+			entries = append(entries, gollections.Pair[K, V]{First: key, Second: e.value})
+		}
+	}
+	return entries
 }
 
-func (c *concurrentCache) Put(key interface{}, value interface{}) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.cache.Put(key, value)
+// accessBufferSize is the number of reads a shard of a concurrent cache records before they are
+// applied to the eviction policy.
+const accessBufferSize = 32
+
+// An access records that a key was read, stamped with the order of the read.
+type access[K comparable] struct {
+	key   K
+	stamp uint64
 }
 
-func (c *concurrentCache) SetMaxSize(maxSize int) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.cache.SetMaxSize(maxSize)
+// A shard is a part of a concurrent cache guarded by its own lock. Reads of the shard are recorded
+// in a buffer, so that readers do not wait for the lock of the eviction policy.
+type shard[K comparable, V any] struct {
+	mutex    sync.RWMutex
+	store    store[K, V]
+	buffer   sync.Mutex
+	accesses []access[K]
 }
 
-func (c *concurrentCache) Size() int {
-	c.mutex.RLock()
-	c.mutex.RUnlock()
-	return c.cache.Size()
+// A concurrentCache splits its entries between shards by the hash of their keys, so that
+// goroutines reading keys of different shards do not wait for each other. One eviction policy
+// orders the entries of all shards. Reads are recorded by their shard and applied to the policy in
+// the order they happened before the policy is next used, so the policy sees the same sequence of
+// operations as it would in a cache that is not concurrent. Changes to the cache hold the lock of
+// the policy and then the lock of a shard.
+type concurrentCache[K comparable, V any] struct {
+	compound[K, V]
+	shards []*shard[K, V]
+	hash   func(K) uint64
+	stamps atomic.Uint64
+	size   atomic.Int64
+	weight atomic.Int64
+	mutex  sync.Mutex
+	limits[K]
+	weigher  Weigher[K, V]
+	ttl      time.Duration
+	clock    Clock
+	notifier *notifier[K, V]
+	stats    *statsCounter
+	recorder StatsRecorder
+	done     chan struct{}
+	closing  sync.Once
 }
 
-func (c *concurrentCache) Remove(key interface{}) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.cache.Remove(key)
+// seed is the seed used to hash keys that have no key equality strategy.
+var seed = maphash.MakeSeed()
+
+// shardOf gets the shard that holds the entry for the supplied key.
+func (c *concurrentCache[K, V]) shardOf(key K) *shard[K, V] {
+	return c.shards[c.hash(key)%uint64(len(c.shards))]
 }
 
-// NewCache initializes a new cache.
-func NewCache(maxSize int) Cache {
-	return &cache{
-		maxSize: maxSize,
-		values:  map[interface{}]interface{}{},
-		keys:    gollections.NewLinkedList(),
+// record buffers a read of a key held by a shard, and applies the buffered reads of all shards to
+// the policy if the buffer is full and the policy is not in use.
+func (c *concurrentCache[K, V]) record(s *shard[K, V], key K) {
+	s.buffer.Lock()
+	s.accesses = append(s.accesses, access[K]{key: key, stamp: c.stamps.Add(1)})
+	full := len(s.accesses) >= accessBufferSize
+	s.buffer.Unlock()
+	if full && c.mutex.TryLock() {
+		c.drain()
+		c.mutex.Unlock()
 	}
 }
 
-// NewConcurrentCache initializes a new thead-safe cache.
+// drain applies the buffered reads of all shards to the policy in the order they happened. The
+// lock of the policy must be held.
+func (c *concurrentCache[K, V]) drain() {
+	var accesses []access[K]
+	for _, s := range c.shards {
+		s.buffer.Lock()
+		accesses = append(accesses, s.accesses...)
+		s.accesses = s.accesses[:0]
+		s.buffer.Unlock()
+	}
+	slices.SortFunc(accesses, func(a, b access[K]) int {
+		return cmp.Compare(a.stamp, b.stamp)
+	})
+	for _, a := range accesses {
+		c.policy.Access(a.key)
+	}
+}
+
+// update makes a change to the cache while holding the lock of the policy, after applying the
+// buffered reads to the policy. The entries removed by the change are queued for an asynchronous
+// listener before the lock is released, so that it is told about them in order, while any other
+// listener is told about them once the lock is released.
+func (c *concurrentCache[K, V]) update(change func() []removal[K, V]) {
+	removals := func() []removal[K, V] {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.drain()
+		return c.notifier.enqueue(change())
+	}()
+	c.notifier.notify(removals)
+}
+
+// always is a condition of remove that is always met.
+func always[V any](entry[V]) bool {
+	return true
+}
+
+// remove deletes the entry of a key in canonical form from a shard if it is held and the supplied
+// condition is met. The lock of the policy must be held.
+func (c *concurrentCache[K, V]) remove(s *shard[K, V], key K, cause RemovalCause, condition func(entry[V]) bool) []removal[K, V] {
+	s.mutex.Lock()
+	e, ok := s.store.entries[key]
+	if ok = ok && condition(e); ok {
+		s.store.remove(key)
+	}
+	s.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	c.size.Add(-1)
+	c.weight.Add(-e.weight)
+	c.policy.Remove(key)
+	return c.notifier.add(nil, key, e.value, e.causeOf(cause, c.clock))
+}
+
+// prune evicts entries selected by the policy until the cache holds no more than the maximum
+// number and weight of entries. The evicted entries are appended to evicted unless it is nil. The
+// lock of the policy must be held.
+func (c *concurrentCache[K, V]) prune(removals []removal[K, V], evicted *[]gollections.Pair[K, V]) []removal[K, V] {
+	full := false
+	for c.exceeded(int(c.size.Load()), c.weight.Load()) {
+		key, ok := c.policy.Evict()
+		if !ok {
+			break
+		}
+		s := c.shardOf(key)
+		s.mutex.Lock()
+		e := s.store.remove(key)
+		s.mutex.Unlock()
+		c.size.Add(-1)
+		c.weight.Add(-e.weight)
+		if evicted != nil {
+			*evicted = append(*evicted, gollections.Pair[K, V]{First: key, Second: e.value})
+		}
+		removals = c.notifier.add(removals, key, e.value, e.causeOf(CauseSize, c.clock))
+		full = true
+	}
+	c.resize(int(c.size.Load()), full)
+	return removals
+}
+
+// removeExpired removes the expired entries of all shards. The lock of the policy is released
+// between shards, so that other goroutines can change the cache meanwhile.
+func (c *concurrentCache[K, V]) removeExpired() {
+	for _, s := range c.shards {
+		c.update(func() []removal[K, V] {
+			s.mutex.Lock()
+			keys := s.store.expired(c.clock)
+			var removals []removal[K, V]
+			var weight int64
+			for _, key := range keys {
+				e := s.store.remove(key)
+				weight += e.weight
+				removals = c.notifier.add(removals, key, e.value, CauseExpired)
+			}
+			s.mutex.Unlock()
+			c.size.Add(-int64(len(keys)))
+			c.weight.Add(-weight)
+			for _, key := range keys {
+				c.policy.Remove(key)
+			}
+			return removals
+		})
+	}
+}
+
+// janitor removes expired entries each time the supplied interval passes, until the cache is
+// closed. The first interval is the supplied channel. Each interval starts before entries are
+// removed, so that removals are not delayed by the time taken to remove entries.
+func (c *concurrentCache[K, V]) janitor(wake <-chan time.Time, interval time.Duration) {
+	for {
+		select {
+		case <-wake:
+			select {
+			case <-c.done:
+				// the cache was closed while waiting
+				return
+			default:
+			}
+			wake = c.clock.After(interval)
+			c.removeExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *concurrentCache[K, V]) Clear() {
+	c.update(func() []removal[K, V] {
+		var removals []removal[K, V]
+		for _, s := range c.shards {
+			s.mutex.Lock()
+			for key, e := range s.store.entries {
+				removals = c.notifier.add(removals, key, e.value, CauseCleared)
+			}
+			s.store.clear()
+			s.mutex.Unlock()
+		}
+		c.policy.Clear()
+		c.size.Store(0)
+		c.weight.Store(0)
+		return removals
+	})
+}
+
+func (c *concurrentCache[K, V]) Close() {
+	c.closing.Do(func() {
+		close(c.done)
+		c.notifier.close()
+	})
+}
+
+// compute applies a computation to the entry of a key while holding the lock of the policy, so
+// that no other goroutine changes the cache meanwhile.
+func (c *concurrentCache[K, V]) compute(key K, computation computation[V]) (V, bool, error) {
+	var value V
+	var held bool
+	var err error
+	c.update(func() []removal[K, V] {
+		s := c.shardOf(key)
+		s.mutex.RLock()
+		key, e, ok := s.store.lookup(key)
+		s.mutex.RUnlock()
+		live := ok && !e.expired(c.clock)
+		var zero V
+		if !live {
+			e.value = zero
+		}
+		var outcome outcome
+		if value, outcome, err = computation(e.value, live); err != nil {
+			value = zero
+			return nil
+		}
+		switch outcome {
+		case keep:
+			value, held = e.value, live
+			if live {
+				c.policy.Access(key)
+			} else if ok {
+				return c.remove(s, key, CauseExpired, always)
+			}
+			return nil
+		case discard:
+			value = zero
+			if ok {
+				return c.remove(s, key, CauseExplicit, always)
+			}
+			return nil
+		}
+		var removals []removal[K, V]
+		if removals, err = c.put(key, value, c.ttl); err != nil {
+			value = zero
+		}
+		held = err == nil
+		return removals
+	})
+	return value, held, err
+}
+
+func (c *concurrentCache[K, V]) Contains(key K) bool {
+	s := c.shardOf(key)
+	s.mutex.RLock()
+	_, e, ok := s.store.lookup(key)
+	s.mutex.RUnlock()
+	return ok && !e.expired(c.clock)
+}
+
+func (c *concurrentCache[K, V]) Get(key K) (V, error) {
+	value, err := c.get(key)
+	if c.recorder != nil {
+		if err == nil {
+			c.recorder.RecordHit()
+		} else {
+			c.recorder.RecordMiss()
+		}
+	}
+	return value, err
+}
+
+// get retrieves a value from the cache without recording a hit or miss.
+func (c *concurrentCache[K, V]) get(key K) (V, error) {
+	s := c.shardOf(key)
+	s.mutex.RLock()
+	key, e, ok := s.store.lookup(key)
+	s.mutex.RUnlock()
+	if !ok || e.expired(c.clock) {
+		if ok {
+			c.update(func() []removal[K, V] {
+				// the entry may have been replaced since it was read
+				return c.remove(s, key, CauseExpired, func(e entry[V]) bool {
+					return e.expired(c.clock)
+				})
+			})
+		}
+		var zero V
+		return zero, gollections.ErrNoSuchElement
+	}
+	c.record(s, key)
+	return e.value, nil
+}
+
+func (c *concurrentCache[K, V]) GetIfPresent(key K) (V, error) {
+	return c.Get(key)
+}
+
+func (c *concurrentCache[K, V]) Put(key K, value V) error {
+	return c.PutWithTTL(key, value, c.ttl)
+}
+
+func (c *concurrentCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	var err error
+	c.update(func() []removal[K, V] {
+		var removals []removal[K, V]
+		removals, err = c.put(key, value, ttl)
+		return removals
+	})
+	return err
+}
+
+// put adds or updates an entry that expires after the supplied time to live. The lock of the
+// policy must be held.
+func (c *concurrentCache[K, V]) put(key K, value V, ttl time.Duration) ([]removal[K, V], error) {
+	weight := weigh(c.weigher, key, value)
+	if err := c.admit(weight); err != nil {
+		return nil, err
+	}
+	s := c.shardOf(key)
+	s.mutex.Lock()
+	key, old, replaced := s.store.put(key, newEntry(value, weight, ttl, c.clock))
+	s.mutex.Unlock()
+	c.weight.Add(weight - old.weight)
+	var removals []removal[K, V]
+	switch {
+	case !replaced:
+		c.size.Add(1)
+		c.policy.Add(key)
+	case old.expired(c.clock):
+		// an expired entry is replaced by a new entry rather than updated
+		c.policy.Remove(key)
+		c.policy.Add(key)
+	default:
+		c.policy.Access(key)
+	}
+	if replaced {
+		removals = c.notifier.add(removals, key, old.value, old.causeOf(CauseReplaced, c.clock))
+	}
+	return c.prune(removals, nil), nil
+}
+
+func (c *concurrentCache[K, V]) Peek(key K) (V, error) {
+	s := c.shardOf(key)
+	s.mutex.RLock()
+	_, e, ok := s.store.lookup(key)
+	s.mutex.RUnlock()
+	if !ok || e.expired(c.clock) {
+		var zero V
+		return zero, gollections.ErrNoSuchElement
+	}
+	return e.value, nil
+}
+
+func (c *concurrentCache[K, V]) Resize(maxSize int) []gollections.Pair[K, V] {
+	var evicted []gollections.Pair[K, V]
+	c.update(func() []removal[K, V] {
+		c.maxSize = maxSize
+		c.resize(int(c.size.Load()), false)
+		return c.prune(nil, &evicted)
+	})
+	return evicted
+}
+
+func (c *concurrentCache[K, V]) SetMaxSize(maxSize int) {
+	c.Resize(maxSize)
+}
+
+func (c *concurrentCache[K, V]) SetMaxWeight(maxWeight int64) {
+	c.update(func() []removal[K, V] {
+		c.maxWeight = maxWeight
+		c.resize(int(c.size.Load()), false)
+		return c.prune(nil, nil)
+	})
+}
+
+// Size gets the current number of entries in the cache. The size may not include changes that
+// are still being made by other goroutines.
+func (c *concurrentCache[K, V]) Size() int {
+	return int(c.size.Load())
+}
+
+func (c *concurrentCache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// Weight gets the current total weight of the entries in the cache. The weight may not include
+// changes that are still being made by other goroutines.
+func (c *concurrentCache[K, V]) Weight() int64 {
+	return c.weight.Load()
+}
+
+func (c *concurrentCache[K, V]) Remove(key K) {
+	c.update(func() []removal[K, V] {
+		s := c.shardOf(key)
+		s.mutex.RLock()
+		key, _, _ := s.store.lookup(key)
+		s.mutex.RUnlock()
+		return c.remove(s, key, CauseExplicit, always)
+	})
+}
+
+// snapshot gets the unexpired entries of the cache from least to most recently used. The lock of
+// the policy is held so that the entries do not change while they are read.
+func (c *concurrentCache[K, V]) snapshot() []gollections.Pair[K, V] {
+	var entries []gollections.Pair[K, V]
+	c.update(func() []removal[K, V] {
+		for _, s := range c.shards {
+			s.mutex.RLock()
+		}
+		for _, key := range c.recency.keys() {
+			if e := c.shardOf(key).store.entries[key]; !e.expired(c.clock) {
+				entries = append(entries, gollections.Pair[K, V]{First: key, Second: e.value})
+			}
+		}
+		for _, s := range c.shards {
+			s.mutex.RUnlock()
+		}
+		return nil
+	})
+	return entries
+}
+
+// NewCacheOf initializes a new cache. Entries are evicted by the least recently used policy unless
+// WithEvictionPolicy is used. The cache has no janitor, so expired entries are only removed when
+// they are read or evicted.
+func NewCacheOf[K comparable, V any](maxSize int, options ...Option[K, V]) CacheOf[K, V] {
+	o := newOptions(options)
+	c := &cache[K, V]{
+		limits:  newLimits(o.newPolicy()),
+		store:   newStore[K, V](o.keyEquality),
+		weigher: o.weigher,
+		ttl:     o.ttl,
+		clock:   o.clock,
+	}
+	c.compound = compound[K, V]{compute: c.compute, snapshot: c.snapshot}
+	c.stats, c.recorder = newRecorder(o)
+	c.notifier = newNotifier(o, c.recorder)
+	c.maxWeight = o.maxWeight
+	c.SetMaxSize(maxSize)
+	return c
+}
+
+// NewConcurrentCacheOf initializes a new thead-safe cache. Entries are split between shards, see
+// WithShards, and one eviction policy selects the entries to evict from the whole cache when it is
+// full. If WithJanitor is used, the cache must be closed to stop its janitor.
+func NewConcurrentCacheOf[K comparable, V any](maxSize int, options ...Option[K, V]) CacheOf[K, V] {
+	return newConcurrentCache(maxSize, newOptions(options))
+}
+
+// A Cache represents a store of untyped keys and values.
+//
+// Deprecated: Use CacheOf, which holds keys and values of a single type each.
+type Cache = CacheOf[interface{}, interface{}]
+
+// NewCache initializes a new cache of untyped keys and values.
+//
+// Deprecated: Use NewCacheOf, which holds keys and values of a single type each.
+func NewCache(maxSize int) Cache {
+	return NewCacheOf[interface{}, interface{}](maxSize)
+}
+
+// NewConcurrentCache initializes a new thread-safe cache of untyped keys and values.
+//
+// Deprecated: Use NewConcurrentCacheOf, which holds keys and values of a single type each.
 func NewConcurrentCache(maxSize int) Cache {
-	return &concurrentCache{
-		cache: cache{
-			maxSize: maxSize,
-			values:  map[interface{}]interface{}{},
-			keys:    gollections.NewLinkedList(),
+	return NewConcurrentCacheOf[interface{}, interface{}](maxSize)
+}
+
+// newConcurrentCache initializes a concurrent cache with the supplied configuration.
+func newConcurrentCache[K comparable, V any](maxSize int, o options[K, V]) *concurrentCache[K, V] {
+	c := &concurrentCache[K, V]{
+		shards: make([]*shard[K, V], max(o.shards, 1)),
+		hash: func(key K) uint64 {
+			return maphash.Comparable(seed, key)
 		},
-		mutex: &sync.RWMutex{},
+		limits:  newLimits(o.newPolicy()),
+		weigher: o.weigher,
+		ttl:     o.ttl,
+		clock:   o.clock,
+		done:    make(chan struct{}),
+	}
+	c.compound = compound[K, V]{compute: c.compute, snapshot: c.snapshot}
+	if o.keyEquality != nil {
+		// keys that are equal by the strategy must be held by the same shard
+		c.hash = o.keyEquality.Hash
+	}
+	for i := range c.shards {
+		c.shards[i] = &shard[K, V]{store: newStore[K, V](o.keyEquality)}
+	}
+	c.stats, c.recorder = newRecorder(o)
+	c.notifier = newNotifier(o, c.recorder)
+	c.maxWeight = o.maxWeight
+	c.SetMaxSize(maxSize)
+	if o.janitorInterval > 0 {
+		go c.janitor(c.clock.After(o.janitorInterval), o.janitorInterval)
 	}
+	return c
 }
@@ -1,7 +1,12 @@
 package cache_test
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/bsladewski/gollections"
 	"github.com/bsladewski/gollections/cache"
@@ -71,3 +76,256 @@ func TestCache(t *testing.T) {
 		t.Fatalf("expected no such element error, got %v, err %v", got, err)
 	}
 }
+
+// TestCacheTyped tests a cache with concrete key and value types.
+func TestCacheTyped(t *testing.T) {
+	c := cache.NewConcurrentCacheOf[string, int](2)
+	if got, err := c.Get("a"); got != 0 || err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected zero value and no such element error, got %d, err: %v", got, err)
+	}
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	if got, err := c.Get("c"); err != nil || got != 3 {
+		t.Fatalf("expected 3, got %d, err: %v", got, err)
+	}
+	if _, err := c.Get("a"); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+}
+
+// TestCacheKeyEquality tests a cache that compares keys using a custom equality strategy.
+func TestCacheKeyEquality(t *testing.T) {
+	equality := gollections.KeyEquality(strings.ToLower)
+	for _, c := range []cache.CacheOf[string, int]{
+		cache.NewCacheOf(2, cache.WithKeyEquality[string, int](equality)),
+		cache.NewConcurrentCacheOf(2, cache.WithKeyEquality[string, int](equality)),
+	} {
+		c.Put("A", 1)
+		c.Put("a", 2)
+		if size := c.Size(); size != 1 {
+			t.Fatalf("expected size 1, got %d", size)
+		}
+		if got, err := c.Get("A"); err != nil || got != 2 {
+			t.Fatalf("expected 2, got %d, err: %v", got, err)
+		}
+		c.Put("b", 3)
+		c.Put("C", 4)
+		if _, err := c.Get("a"); err != gollections.ErrNoSuchElement {
+			t.Fatalf("expected no such element error, got %v", err)
+		}
+		c.Remove("B")
+		if _, err := c.Get("b"); err != gollections.ErrNoSuchElement || c.Size() != 1 {
+			t.Fatalf("expected b to be removed, err: %v", err)
+		}
+		c.Put("a", 5)
+		if got, err := c.Get("A"); err != nil || got != 5 {
+			t.Fatalf("expected 5, got %d, err: %v", got, err)
+		}
+	}
+}
+
+// TestConcurrentCacheStress uses a concurrent cache from many goroutines at once. Run with -race
+// to check the cache for data races.
+func TestConcurrentCacheStress(t *testing.T) {
+	const goroutines, operations, keys = 8, 2000, 64
+	for _, shards := range []int{1, 4, 16} {
+		c := cache.NewConcurrentCacheOf(32, cache.WithShards[int, int](shards))
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				r := rand.New(rand.NewPCG(uint64(g), uint64(shards)))
+				for i := 0; i < operations; i++ {
+					key := r.IntN(keys)
+					switch n := r.IntN(100); {
+					case n < 50:
+						if value, err := c.Get(key); err == nil && value != key*10 {
+							t.Errorf("expected %d, got %d", key*10, value)
+							return
+						}
+					case n < 85:
+						c.Put(key, key*10)
+					case n < 97:
+						c.Remove(key)
+					case n < 99:
+						c.SetMaxSize(16 + r.IntN(32))
+					default:
+						c.Size()
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		c.SetMaxSize(20)
+		held := 0
+		for key := 0; key < keys; key++ {
+			if _, err := c.Get(key); err == nil {
+				held++
+			}
+		}
+		if size := c.Size(); size != held || size > 20 {
+			t.Fatalf("expected size %d of at most 20, got %d", held, size)
+		}
+	}
+}
+
+// TestConcurrentCacheEviction tests that a sharded cache evicts the least recently used entry of
+// the whole cache.
+func TestConcurrentCacheEviction(t *testing.T) {
+	c := cache.NewConcurrentCacheOf(100, cache.WithShards[int, int](8))
+	for i := 0; i < 100; i++ {
+		c.Put(i, i)
+	}
+	for i := 0; i < 50; i++ {
+		c.Get(i)
+	}
+	for i := 100; i < 150; i++ {
+		c.Put(i, i)
+	}
+	for i := 0; i < 150; i++ {
+		_, err := c.Get(i)
+		if evicted := i >= 50 && i < 100; evicted != (err != nil) {
+			t.Fatalf("expected key %d evicted: %t, got err: %v", i, evicted, err)
+		}
+	}
+}
+
+// BenchmarkConcurrentCache measures a read heavy workload with one shard and the default number
+// of shards.
+func BenchmarkConcurrentCache(b *testing.B) {
+	for _, shards := range []int{1, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			c := cache.NewConcurrentCacheOf(1024, cache.WithShards[int, int](shards))
+			for i := 0; i < 1024; i++ {
+				c.Put(i, i)
+			}
+			b.RunParallel(func(pb *testing.PB) {
+				r := rand.New(rand.NewPCG(rand.Uint64(), 0))
+				for pb.Next() {
+					key := r.IntN(2048)
+					if _, err := c.Get(key); err != nil {
+						c.Put(key, key)
+					}
+				}
+			})
+		})
+	}
+}
+
+// cacheBenchmarkSizes are the numbers of entries of the caches used by benchmarks.
+var cacheBenchmarkSizes = []int{1_000, 10_000, 100_000, 1_000_000, 10_000_000}
+
+// BenchmarkCache measures getting, updating and evicting entries of full caches of increasing
+// size. No operation does work proportional to the size of the cache, but each reads the entries
+// and the eviction policy at random, so operations slow down several times over once the cache no
+// longer fits in the processor caches.
+func BenchmarkCache(b *testing.B) {
+	for _, size := range cacheBenchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			c := cache.NewCacheOf[int, int](size)
+			for i := 0; i < size; i++ {
+				c.Put(i, i)
+			}
+			r := rand.New(rand.NewPCG(1, 2))
+			b.Run("Get", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					c.Get(r.IntN(size))
+				}
+			})
+			b.Run("Put", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					key := r.IntN(size)
+					c.Put(key, key)
+				}
+			})
+			b.Run("Evict", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					c.Put(size+i, i)
+				}
+			})
+		})
+	}
+}
+
+// TestCacheTTL tests that entries of both kinds of cache expire after their time to live.
+func TestCacheTTL(t *testing.T) {
+	clock := cache.NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	options := []cache.Option[string, int]{
+		cache.WithTTL[string, int](time.Minute),
+		cache.WithClock[string, int](clock),
+	}
+	for _, c := range []cache.CacheOf[string, int]{
+		cache.NewCacheOf(10, options...),
+		cache.NewConcurrentCacheOf(10, options...),
+	} {
+		c.Put("default", 1)
+		c.PutWithTTL("short", 2, time.Second)
+		c.PutWithTTL("forever", 3, 0)
+		clock.Advance(time.Second)
+		if _, err := c.Get("short"); err != gollections.ErrNoSuchElement {
+			t.Fatalf("expected short to expire, got err: %v", err)
+		}
+		if size := c.Size(); size != 2 {
+			t.Fatalf("expected size 2, got %d", size)
+		}
+		// replacing an entry resets its time to live
+		clock.Advance(30 * time.Second)
+		c.Put("default", 4)
+		clock.Advance(45 * time.Second)
+		if got, err := c.Get("default"); err != nil || got != 4 {
+			t.Fatalf("expected 4, got %d, err: %v", got, err)
+		}
+		clock.Advance(15 * time.Second)
+		if _, err := c.Get("default"); err != gollections.ErrNoSuchElement {
+			t.Fatalf("expected default to expire, got err: %v", err)
+		}
+		clock.Advance(24 * time.Hour)
+		if got, err := c.Get("forever"); err != nil || got != 3 {
+			t.Fatalf("expected 3, got %d, err: %v", got, err)
+		}
+		// an expired entry that is put again is held again
+		c.PutWithTTL("short", 5, time.Second)
+		if got, err := c.Get("short"); err != nil || got != 5 || c.Size() != 2 {
+			t.Fatalf("expected 5 and size 2, got %d and size %d, err: %v", got, c.Size(), err)
+		}
+		c.Close()
+	}
+}
+
+// TestConcurrentCacheJanitor tests that the janitor of a concurrent cache removes expired entries
+// without them being read, and stops when the cache is closed.
+func TestConcurrentCacheJanitor(t *testing.T) {
+	clock := cache.NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := cache.NewConcurrentCacheOf(10,
+		cache.WithClock[int, int](clock),
+		cache.WithJanitor[int, int](time.Minute))
+	defer c.Close()
+	for i := 0; i < 5; i++ {
+		c.PutWithTTL(i, i, time.Duration(i+1)*30*time.Second)
+	}
+	// entries 0 and 1 expire within the first interval
+	clock.Advance(time.Minute)
+	waitForSize(t, c, 3)
+	clock.Advance(time.Minute)
+	waitForSize(t, c, 1)
+	c.Close()
+	c.Close()
+	clock.Advance(time.Minute)
+	if size := c.Size(); size != 1 {
+		t.Fatalf("expected the janitor to stop, got size %d", size)
+	}
+}
+
+// waitForSize waits for a janitor to change the size of a cache to the expected size.
+func waitForSize[K comparable, V any](t *testing.T, c cache.CacheOf[K, V], expected int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Size() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected size %d, got %d", expected, c.Size())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
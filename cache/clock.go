@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// A Clock tells a cache the time, so that entries can expire. Tests can supply a ManualClock to
+// control when entries expire.
+type Clock interface {
+	// Now gets the current time.
+	Now() time.Time
+	// After gets a channel that receives the current time once the supplied duration has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock tells the time using the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the clock of the system. Caches use it unless WithClock is used.
+var SystemClock Clock = systemClock{}
+
+// A timer is a channel waiting for the time of a ManualClock to reach a deadline.
+type timer struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+// A ManualClock is a clock whose time only changes when it is advanced. It is safe for
+// concurrent use.
+type ManualClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []timer
+}
+
+// Now gets the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// After gets a channel that receives the time of the clock once it has been advanced by at least
+// the supplied duration.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	t := timer{deadline: c.now.Add(d), channel: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.channel <- c.now
+	} else {
+		c.timers = append(c.timers, t)
+	}
+	return t.channel
+}
+
+// Advance moves the time of the clock forward, firing the channels whose duration has passed.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if c.now.Before(t.deadline) {
+			pending = append(pending, t)
+		} else {
+			t.channel <- c.now
+		}
+	}
+	clear(c.timers[len(pending):])
+	c.timers = pending
+}
+
+// NewManualClock initializes a clock that starts at the supplied time.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
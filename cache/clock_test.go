@@ -0,0 +1,34 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections/cache"
+)
+
+// TestManualClock tests that a manual clock only fires channels once it is advanced past their
+// duration.
+func TestManualClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := cache.NewManualClock(start)
+	immediate, later := clock.After(0), clock.After(time.Minute)
+	select {
+	case now := <-immediate:
+		if !now.Equal(start) {
+			t.Fatalf("expected %v, got %v", start, now)
+		}
+	default:
+		t.Fatal("expected a channel with no duration to fire immediately")
+	}
+	clock.Advance(59 * time.Second)
+	select {
+	case <-later:
+		t.Fatal("expected the channel not to fire before its duration has passed")
+	default:
+	}
+	clock.Advance(time.Second)
+	if now := <-later; !now.Equal(start.Add(time.Minute)) || !clock.Now().Equal(now) {
+		t.Fatalf("expected %v, got %v", start.Add(time.Minute), now)
+	}
+}
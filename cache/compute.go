@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"iter"
+
+	"github.com/bsladewski/gollections"
+)
+
+// An outcome is the change a computation makes to the entry of a key.
+type outcome int
+
+const (
+	// keep leaves the entry unchanged, and records that it was read if it is held.
+	keep outcome = iota
+	// set adds or updates the entry.
+	set
+	// discard removes the entry if it is held.
+	discard
+)
+
+// A computation decides the change to the entry of a key from its current value, and whether the
+// cache holds it.
+type computation[V any] func(value V, ok bool) (V, outcome, error)
+
+// compound implements the compound operations and iterators of a cache in terms of two functions
+// of the cache. compute applies a computation to the entry of a key atomically, and returns the
+// value held for the key afterwards and whether there is one. snapshot gets the unexpired entries
+// of the cache from least to most recently used. Iterators take a snapshot each time they are
+// used, so the cache can be changed while iterating.
+type compound[K comparable, V any] struct {
+	compute  func(key K, computation computation[V]) (V, bool, error)
+	snapshot func() []gollections.Pair[K, V]
+}
+
+func (c compound[K, V]) Compute(key K, remap func(key K, value V, ok bool) (V, bool)) (V, bool, error) {
+	return c.compute(key, func(value V, ok bool) (V, outcome, error) {
+		value, ok = remap(key, value, ok)
+		if !ok {
+			return value, discard, nil
+		}
+		return value, set, nil
+	})
+}
+
+func (c compound[K, V]) ComputeIfAbsent(key K, compute func(key K) (V, error)) (V, error) {
+	value, _, err := c.compute(key, func(value V, ok bool) (V, outcome, error) {
+		if ok {
+			return value, keep, nil
+		}
+		value, err := compute(key)
+		return value, set, err
+	})
+	return value, err
+}
+
+func (c compound[K, V]) ComputeIfPresent(key K, remap func(key K, value V) (V, bool)) (V, bool, error) {
+	return c.compute(key, func(value V, ok bool) (V, outcome, error) {
+		if !ok {
+			return value, keep, nil
+		}
+		if value, ok = remap(key, value); !ok {
+			return value, discard, nil
+		}
+		return value, set, nil
+	})
+}
+
+func (c compound[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range c.snapshot() {
+			if !yield(e.First, e.Second) {
+				return
+			}
+		}
+	}
+}
+
+func (c compound[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, e := range c.snapshot() {
+			if !yield(e.First) {
+				return
+			}
+		}
+	}
+}
+
+func (c compound[K, V]) Merge(key K, value V, merge func(old, value V) (V, bool)) (V, bool, error) {
+	return c.compute(key, func(old V, ok bool) (V, outcome, error) {
+		if !ok {
+			return value, set, nil
+		}
+		merged, ok := merge(old, value)
+		if !ok {
+			return merged, discard, nil
+		}
+		return merged, set, nil
+	})
+}
+
+func (c compound[K, V]) PutIfAbsent(key K, value V) (V, bool, error) {
+	loaded := false
+	value, _, err := c.compute(key, func(old V, ok bool) (V, outcome, error) {
+		if loaded = ok; ok {
+			return old, keep, nil
+		}
+		return value, set, nil
+	})
+	return value, loaded, err
+}
+
+func (c compound[K, V]) Replace(key K, value V) (V, bool, error) {
+	var previous V
+	replaced := false
+	_, _, err := c.compute(key, func(old V, ok bool) (V, outcome, error) {
+		if !ok {
+			return old, keep, nil
+		}
+		previous, replaced = old, true
+		return value, set, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, false, err
+	}
+	return previous, replaced, nil
+}
+
+func (c compound[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, e := range c.snapshot() {
+			if !yield(e.Second) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package cache_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections"
+	"github.com/bsladewski/gollections/cache"
+)
+
+// TestCacheCompound tests the compound operations of both kinds of cache.
+func TestCacheCompound(t *testing.T) {
+	for _, c := range []cache.CacheOf[string, int]{
+		cache.NewCacheOf[string, int](10),
+		cache.NewConcurrentCacheOf[string, int](10),
+	} {
+		if value, loaded, err := c.PutIfAbsent("a", 1); err != nil || loaded || value != 1 {
+			t.Fatalf("expected 1 to be added, got %d and %t, err: %v", value, loaded, err)
+		}
+		if value, loaded, err := c.PutIfAbsent("a", 2); err != nil || !loaded || value != 1 {
+			t.Fatalf("expected 1 to be held, got %d and %t, err: %v", value, loaded, err)
+		}
+		if value, replaced, err := c.Replace("b", 2); err != nil || replaced || value != 0 || c.Contains("b") {
+			t.Fatalf("expected nothing to be replaced, got %d and %t, err: %v", value, replaced, err)
+		}
+		if value, replaced, err := c.Replace("a", 2); err != nil || !replaced || value != 1 {
+			t.Fatalf("expected 1 to be replaced, got %d and %t, err: %v", value, replaced, err)
+		}
+		failure := errors.New("failure")
+		if _, err := c.ComputeIfAbsent("b", func(string) (int, error) { return 0, failure }); err != failure {
+			t.Fatalf("expected failure, got %v", err)
+		}
+		if c.Contains("b") {
+			t.Fatal("expected nothing to be added")
+		}
+		if value, err := c.ComputeIfAbsent("b", func(key string) (int, error) { return len(key), nil }); err != nil || value != 1 {
+			t.Fatalf("expected 1, got %d, err: %v", value, err)
+		}
+		if value, err := c.ComputeIfAbsent("b", func(string) (int, error) { return 5, nil }); err != nil || value != 1 {
+			t.Fatalf("expected 1 to be held, got %d, err: %v", value, err)
+		}
+		increment := func(_ string, value int) (int, bool) { return value + 1, true }
+		if value, ok, err := c.ComputeIfPresent("b", increment); err != nil || !ok || value != 2 {
+			t.Fatalf("expected 2, got %d and %t, err: %v", value, ok, err)
+		}
+		if value, ok, err := c.ComputeIfPresent("c", increment); err != nil || ok || value != 0 || c.Contains("c") {
+			t.Fatalf("expected nothing to be computed, got %d and %t, err: %v", value, ok, err)
+		}
+		count := func(_ string, value int, ok bool) (int, bool) { return value + 1, true }
+		c.Compute("c", count)
+		if value, ok, err := c.Compute("c", count); err != nil || !ok || value != 2 {
+			t.Fatalf("expected 2, got %d and %t, err: %v", value, ok, err)
+		}
+		if value, ok, err := c.Compute("c", func(string, int, bool) (int, bool) { return 0, false }); err != nil || ok || value != 0 || c.Contains("c") {
+			t.Fatalf("expected c to be removed, got %d and %t, err: %v", value, ok, err)
+		}
+		sum := func(old, value int) (int, bool) { return old + value, old+value != 0 }
+		if value, ok, err := c.Merge("d", 3, sum); err != nil || !ok || value != 3 {
+			t.Fatalf("expected 3, got %d and %t, err: %v", value, ok, err)
+		}
+		if value, ok, err := c.Merge("d", 4, sum); err != nil || !ok || value != 7 {
+			t.Fatalf("expected 7, got %d and %t, err: %v", value, ok, err)
+		}
+		if _, ok, err := c.Merge("d", -7, sum); err != nil || ok || c.Contains("d") {
+			t.Fatalf("expected d to be removed, got %t, err: %v", ok, err)
+		}
+		if value, err := c.Peek("a"); err != nil || value != 2 {
+			t.Fatalf("expected 2, got %d, err: %v", value, err)
+		}
+		if _, err := c.Peek("d"); err != gollections.ErrNoSuchElement {
+			t.Fatalf("expected no such element error, got %v", err)
+		}
+		if value, err := c.GetIfPresent("a"); err != nil || value != 2 {
+			t.Fatalf("expected 2, got %d, err: %v", value, err)
+		}
+		if _, err := c.GetIfPresent("d"); err != gollections.ErrNoSuchElement {
+			t.Fatalf("expected no such element error, got %v", err)
+		}
+		if size := c.Size(); size != 2 {
+			t.Fatalf("expected 2 entries, got %d", size)
+		}
+	}
+}
+
+// TestCacheCompoundWeight tests that compound operations reject entries that are too heavy.
+func TestCacheCompoundWeight(t *testing.T) {
+	options := []cache.Option[int, string]{
+		cache.WithWeigher(length),
+		cache.WithMaxWeight[int, string](4),
+	}
+	for _, c := range []cache.CacheOf[int, string]{
+		cache.NewCacheOf(0, options...),
+		cache.NewConcurrentCacheOf(0, options...),
+	} {
+		c.Put(0, "a")
+		if _, _, err := c.PutIfAbsent(1, "aaaaa"); !errors.Is(err, cache.ErrTooHeavy) {
+			t.Fatalf("expected too heavy error, got %v", err)
+		}
+		if _, err := c.ComputeIfAbsent(1, func(int) (string, error) { return "aaaaa", nil }); !errors.Is(err, cache.ErrTooHeavy) {
+			t.Fatalf("expected too heavy error, got %v", err)
+		}
+		if value, replaced, err := c.Replace(0, "aaaaa"); !errors.Is(err, cache.ErrTooHeavy) || replaced || value != "" {
+			t.Fatalf("expected too heavy error, got %q and %t, err: %v", value, replaced, err)
+		}
+		if value, err := c.Get(0); err != nil || value != "a" || c.Size() != 1 {
+			t.Fatalf("expected the cache to be unchanged, got %q and size %d, err: %v", value, c.Size(), err)
+		}
+	}
+}
+
+// TestCacheCompoundExpiry tests that compound operations treat expired entries as missing.
+func TestCacheCompoundExpiry(t *testing.T) {
+	for _, newCache := range []func(...cache.Option[string, int]) cache.CacheOf[string, int]{
+		func(options ...cache.Option[string, int]) cache.CacheOf[string, int] {
+			return cache.NewCacheOf(10, options...)
+		},
+		func(options ...cache.Option[string, int]) cache.CacheOf[string, int] {
+			return cache.NewConcurrentCacheOf(10, options...)
+		},
+	} {
+		clock := cache.NewManualClock(time.Unix(0, 0))
+		var causes []cache.RemovalCause
+		c := newCache(cache.WithClock[string, int](clock), cache.WithTTL[string, int](time.Minute),
+			cache.OnEvict(func(_ string, _ int, cause cache.RemovalCause) {
+				causes = append(causes, cause)
+			}))
+		c.Put("a", 1)
+		c.Put("b", 2)
+		clock.Advance(time.Minute)
+		if c.Contains("a") {
+			t.Fatal("expected a to have expired")
+		}
+		if _, err := c.Peek("a"); err != gollections.ErrNoSuchElement {
+			t.Fatalf("expected no such element error, got %v", err)
+		}
+		if value, loaded, err := c.PutIfAbsent("a", 3); err != nil || loaded || value != 3 {
+			t.Fatalf("expected 3 to be added, got %d and %t, err: %v", value, loaded, err)
+		}
+		if _, replaced, _ := c.Replace("b", 4); replaced || c.Size() != 1 {
+			t.Fatalf("expected b to be removed, got size %d", c.Size())
+		}
+		expected := []cache.RemovalCause{cache.CauseExpired, cache.CauseExpired}
+		if !slices.Equal(causes, expected) {
+			t.Fatalf("expected %v, got %v", expected, causes)
+		}
+	}
+}
+
+// TestCacheResize tests that resizing a cache returns the evicted entries in eviction order.
+func TestCacheResize(t *testing.T) {
+	for _, c := range []cache.CacheOf[int, int]{
+		cache.NewCacheOf[int, int](5),
+		cache.NewConcurrentCacheOf[int, int](5),
+	} {
+		for i := 0; i < 5; i++ {
+			c.Put(i, -i)
+		}
+		c.Get(0)
+		evicted := c.Resize(2)
+		expected := []gollections.Pair[int, int]{{First: 1, Second: -1}, {First: 2, Second: -2}, {First: 3, Second: -3}}
+		if !slices.Equal(evicted, expected) {
+			t.Fatalf("expected %v, got %v", expected, evicted)
+		}
+		if evicted := c.Resize(10); len(evicted) != 0 {
+			t.Fatalf("expected no entries to be evicted, got %v", evicted)
+		}
+	}
+}
+
+// TestConcurrentCacheCompound tests that compound operations of a concurrent cache are atomic.
+func TestConcurrentCacheCompound(t *testing.T) {
+	const goroutines, increments = 8, 1000
+	c := cache.NewConcurrentCacheOf[int, int](10)
+	var added sync.Map
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				c.Merge(0, 1, func(old, value int) (int, bool) { return old + value, true })
+				c.Compute(1, func(_ int, value int, _ bool) (int, bool) { return value + 1, true })
+				if _, loaded, _ := c.PutIfAbsent(2+i%8, g); !loaded {
+					if _, ok := added.LoadOrStore(2+i%8, g); ok {
+						t.Errorf("expected %d to be added once", 2+i%8)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for _, key := range []int{0, 1} {
+		if value, err := c.Get(key); err != nil || value != goroutines*increments {
+			t.Fatalf("expected %d, got %d, err: %v", goroutines*increments, value, err)
+		}
+	}
+}
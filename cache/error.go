@@ -0,0 +1,11 @@
+package cache
+
+import "errors"
+
+var (
+	// ErrLoaderPanicked the loader of an entry panicked while other goroutines waited for it.
+	ErrLoaderPanicked = errors.New("loader panicked")
+
+	// ErrTooHeavy the entry weighs more than the maximum weight of the cache.
+	ErrTooHeavy = errors.New("entry too heavy")
+)
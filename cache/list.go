@@ -0,0 +1,78 @@
+package cache
+
+// A node holds a key tracked by an eviction policy. Nodes are linked into lists that order keys
+// by the criteria of the policy.
+type node[K comparable] struct {
+	key K
+	// segment identifies the list of the policy that holds the node
+	segment  uint8
+	previous *node[K]
+	next     *node[K]
+}
+
+// A keyList is a doubly linked list of nodes. Nodes are linked and unlinked directly, so all
+// operations are O(1). The zero value is an empty list.
+type keyList[K comparable] struct {
+	root   node[K]
+	length int
+}
+
+// init links the root of an empty list to itself.
+func (l *keyList[K]) init() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.previous = &l.root
+	}
+}
+
+// front gets the first node of the list, or nil if the list is empty.
+func (l *keyList[K]) front() *node[K] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// pushBack links a node to the end of the list.
+func (l *keyList[K]) pushBack(n *node[K]) {
+	l.init()
+	n.previous = l.root.previous
+	n.next = &l.root
+	n.previous.next = n
+	l.root.previous = n
+	l.length++
+}
+
+// remove unlinks a node from the list.
+func (l *keyList[K]) remove(n *node[K]) {
+	n.previous.next = n.next
+	n.next.previous = n.previous
+	n.previous = nil
+	n.next = nil
+	l.length--
+}
+
+// moveToBack moves a node of the list to the end of the list.
+func (l *keyList[K]) moveToBack(n *node[K]) {
+	if l.root.previous == n {
+		return
+	}
+	l.remove(n)
+	l.pushBack(n)
+}
+
+// popFront unlinks and returns the first node of the list, or nil if the list is empty.
+func (l *keyList[K]) popFront() *node[K] {
+	n := l.front()
+	if n != nil {
+		l.remove(n)
+	}
+	return n
+}
+
+// clear unlinks all nodes from the list.
+func (l *keyList[K]) clear() {
+	l.root.next = &l.root
+	l.root.previous = &l.root
+	l.length = 0
+}
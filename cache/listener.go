@@ -0,0 +1,117 @@
+package cache
+
+// A RemovalCause describes why an entry was removed from a cache.
+type RemovalCause int
+
+const (
+	// CauseSize the entry was evicted because the cache held too many entries.
+	CauseSize RemovalCause = iota
+	// CauseExpired the entry was removed because its time to live passed.
+	CauseExpired
+	// CauseExplicit the entry was removed by Remove.
+	CauseExplicit
+	// CauseReplaced the value of the entry was replaced by Put.
+	CauseReplaced
+	// CauseCleared the entry was removed by Clear.
+	CauseCleared
+)
+
+// MarshalText gets the name of the cause, so that causes are named when encoded.
+func (c RemovalCause) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// String gets the name of the cause.
+func (c RemovalCause) String() string {
+	switch c {
+	case CauseSize:
+		return "size"
+	case CauseExpired:
+		return "expired"
+	case CauseExplicit:
+		return "explicit"
+	case CauseReplaced:
+		return "replaced"
+	case CauseCleared:
+		return "cleared"
+	}
+	return "unknown"
+}
+
+// A removal records that an entry was removed from a cache.
+type removal[K comparable, V any] struct {
+	key   K
+	value V
+	cause RemovalCause
+}
+
+// A notifier records removed entries with the stats recorder of a cache, and tells the listener of
+// the cache about them, either directly or through a worker. A nil notifier does neither.
+type notifier[K comparable, V any] struct {
+	recorder StatsRecorder
+	listener func(K, V, RemovalCause)
+	async    *worker[removal[K, V]]
+}
+
+// newNotifier initializes the notifier of a cache, or nil if the cache has neither a stats
+// recorder nor a listener.
+func newNotifier[K comparable, V any](o options[K, V], recorder StatsRecorder) *notifier[K, V] {
+	if o.listener == nil && recorder == nil {
+		return nil
+	}
+	n := &notifier[K, V]{recorder: recorder, listener: o.listener}
+	if o.listener != nil && o.asyncListener {
+		n.async = newWorker(func(r removal[K, V]) {
+			o.listener(r.key, r.value, r.cause)
+		})
+	}
+	return n
+}
+
+// add records a removal, and appends it to the supplied removals unless there is no listener to
+// tell about it.
+func (n *notifier[K, V]) add(removals []removal[K, V], key K, value V, cause RemovalCause) []removal[K, V] {
+	if n == nil {
+		return removals
+	}
+	if n.recorder != nil {
+		n.recorder.RecordRemoval(cause)
+	}
+	if n.listener == nil {
+		return removals
+	}
+	return append(removals, removal[K, V]{key: key, value: value, cause: cause})
+}
+
+// enqueue hands removals to the goroutine of an asynchronous listener, and returns the removals
+// that the listener must instead be told about directly with notify. Called while the cache is
+// locked, so that the goroutine gets removals in the order they were made.
+func (n *notifier[K, V]) enqueue(removals []removal[K, V]) []removal[K, V] {
+	if n == nil || n.async == nil {
+		return removals
+	}
+	for i, r := range removals {
+		if !n.async.enqueue(r) {
+			return removals[i:]
+		}
+	}
+	return nil
+}
+
+// notify tells the listener about removals. Removals are told directly once the listener's worker
+// is closed.
+func (n *notifier[K, V]) notify(removals []removal[K, V]) {
+	for _, r := range removals {
+		if n.async == nil || !n.async.enqueue(r) {
+			n.listener(r.key, r.value, r.cause)
+		}
+	}
+}
+
+// close stops the worker of the listener, if any, once it has told the listener about all
+// removals.
+func (n *notifier[K, V]) close() {
+	if n != nil && n.async != nil {
+		n.async.close()
+	}
+}
@@ -0,0 +1,137 @@
+package cache_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections/cache"
+)
+
+// A removals records the entries a listener is told about.
+type removals struct {
+	mutex   sync.Mutex
+	removed []string
+}
+
+// listener records a removed entry.
+func (r *removals) listener(key string, value int, cause cache.RemovalCause) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.removed = append(r.removed, fmt.Sprintf("%s=%d:%s", key, value, cause))
+}
+
+// take gets and forgets the recorded entries.
+func (r *removals) take() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	removed := r.removed
+	r.removed = nil
+	return removed
+}
+
+// TestCacheListener tests that listeners of both kinds of cache are told about every removed entry
+// with its cause.
+func TestCacheListener(t *testing.T) {
+	clock := cache.NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, async := range []bool{false, true} {
+		r := &removals{}
+		onEvict := cache.OnEvict[string, int]
+		if async {
+			onEvict = cache.OnEvictAsync[string, int]
+		}
+		options := []cache.Option[string, int]{onEvict(r.listener), cache.WithClock[string, int](clock)}
+		for _, c := range []cache.CacheOf[string, int]{
+			cache.NewCacheOf(2, options...),
+			cache.NewConcurrentCacheOf(2, options...),
+		} {
+			// wait for an asynchronous listener before checking the removed entries
+			check := func(expected ...string) {
+				t.Helper()
+				if async {
+					c.Close()
+				}
+				removed := r.take()
+				slices.Sort(removed)
+				if !slices.Equal(removed, expected) {
+					t.Fatalf("expected %v, got %v", expected, removed)
+				}
+			}
+			c.Put("a", 1)
+			c.Put("a", 2)
+			check("a=1:replaced")
+			c.Put("b", 3)
+			c.Put("c", 4)
+			check("a=2:size")
+			c.Remove("b")
+			c.Remove("b")
+			check("b=3:explicit")
+			c.PutWithTTL("d", 5, time.Minute)
+			clock.Advance(time.Minute)
+			c.Get("d")
+			check("d=5:expired")
+			c.PutWithTTL("e", 6, time.Minute)
+			clock.Advance(time.Minute)
+			c.Put("e", 7)
+			check("e=6:expired")
+			c.Put("f", 8)
+			check("c=4:size")
+			c.Clear()
+			check("e=7:cleared", "f=8:cleared")
+		}
+	}
+}
+
+// TestConcurrentCacheAsyncListenerOrder tests that an asynchronous listener is told about entries
+// in the order they were removed, even if they were removed by different goroutines.
+func TestConcurrentCacheAsyncListenerOrder(t *testing.T) {
+	const goroutines, merges = 4, 2000
+	var replaced []int
+	c := cache.NewConcurrentCacheOf(10, cache.OnEvictAsync(func(key, value int, cause cache.RemovalCause) {
+		replaced = append(replaced, value)
+	}))
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < merges; i++ {
+				// each merge replaces the value with the next count
+				c.Merge(0, 1, func(old, value int) (int, bool) { return old + value, true })
+			}
+		}()
+	}
+	wg.Wait()
+	c.Close()
+	if len(replaced) != goroutines*merges-1 {
+		t.Fatalf("expected %d replaced values, got %d", goroutines*merges-1, len(replaced))
+	}
+	for i, value := range replaced {
+		if value != i+1 {
+			t.Fatalf("expected replaced value %d to be %d, got %d", i, i+1, value)
+		}
+	}
+}
+
+// TestConcurrentCacheListener tests that a listener may use the cache it listens to.
+func TestConcurrentCacheListener(t *testing.T) {
+	var c cache.CacheOf[int, int]
+	var evicted []int
+	c = cache.NewConcurrentCacheOf(1, cache.OnEvict(func(key, value int, cause cache.RemovalCause) {
+		if cause == cache.CauseSize {
+			evicted = append(evicted, key)
+			// move evicted entries to a negative key, which evicts the next entry
+			if key >= 0 {
+				c.Put(-key-1, value)
+			}
+		}
+	}))
+	c.Put(0, 0)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	if expected := []int{0, 1, -1, -2}; !slices.Equal(evicted, expected) {
+		t.Fatalf("expected %v, got %v", expected, evicted)
+	}
+}
@@ -0,0 +1,402 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bsladewski/gollections"
+)
+
+// A Loader retrieves values that are missing from a cache, typically from a slower store.
+type Loader[K comparable, V any] interface {
+	// Load retrieves the value of a key. Returns gollections.ErrNoSuchElement if the key has no
+	// value.
+	Load(key K) (V, error)
+	// LoadAll retrieves the values of several keys at once. Keys that have no value are omitted
+	// from the result.
+	LoadAll(keys []K) (map[K]V, error)
+}
+
+// LoaderFunc adapts a function that loads a single value to the Loader interface. LoadAll calls
+// the function for each key in turn.
+type LoaderFunc[K comparable, V any] func(key K) (V, error)
+
+// Load calls the function.
+func (f LoaderFunc[K, V]) Load(key K) (V, error) {
+	return f(key)
+}
+
+// LoadAll calls the function for each key, stopping at the first error other than
+// gollections.ErrNoSuchElement.
+func (f LoaderFunc[K, V]) LoadAll(keys []K) (map[K]V, error) {
+	values := make(map[K]V, len(keys))
+	for _, key := range keys {
+		value, err := f(key)
+		if errors.Is(err, gollections.ErrNoSuchElement) {
+			continue
+		}
+		if err != nil {
+			return values, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// A Writer saves the entries written to a cache to a backing store.
+type Writer[K comparable, V any] interface {
+	// Write saves the value of a key.
+	Write(key K, value V) error
+	// Delete removes the value of a key.
+	Delete(key K) error
+}
+
+// A LoadingCacheOf is a thread-safe cache that loads missing values, and optionally writes values
+// to a backing store. When several goroutines miss the same key at once, its value is loaded once
+// and shared between them. Writes and deletes of the same key are made one at a time, so the
+// cache and the backing store end up with the value of the same write.
+type LoadingCacheOf[K comparable, V any] interface {
+	CacheOf[K, V]
+	// Delete removes an entry from the backing store and from the cache. With write-behind, the
+	// entry is removed from the cache at once and from the backing store later.
+	Delete(key K) error
+	// Flush waits for all writes to the backing store that are pending to complete, and returns
+	// the errors of writes that failed since the last flush.
+	Flush() error
+	// GetAll retrieves the values of several keys, loading all missing values at once with the
+	// configured loader. Keys that have no value are omitted from the result. Values loaded by
+	// GetAll are not shared with goroutines loading the same keys at the same time.
+	GetAll(keys []K) (map[K]V, error)
+	// GetOrLoad retrieves a value from the cache, loading it with the supplied function and adding
+	// it to the cache if it is missing. If a value is added to the cache while loading, that value
+	// is kept and returned instead, and the loaded value is not added if the key is put, removed
+	// or cleared while loading. The loaded value is returned with ErrTooHeavy if it is too heavy to
+	// be added.
+	GetOrLoad(key K, loader func(K) (V, error)) (V, error)
+	// Write saves an entry to the backing store and adds it to the cache. With write-behind, the
+	// entry is added to the cache at once and saved to the backing store later. Returns
+	// ErrTooHeavy if the entry was saved but is too heavy to add to the cache.
+	Write(key K, value V) error
+}
+
+// A flight is a load of a value that goroutines can wait for.
+type flight[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+	// stale is set when the key is put, removed or cleared during the load, so that the loaded value
+	// is not added to the cache.
+	stale bool
+}
+
+// A flights de-duplicates concurrent loads of the same key.
+type flights[K comparable, V any] struct {
+	mutex     sync.Mutex
+	canonical *keySet[K]
+	loading   map[K]*flight[V]
+}
+
+// do loads the value of a key, or waits for the load already in progress for that key. The load
+// is called with its flight, which can be checked with stale.
+func (f *flights[K, V]) do(key K, load func(l *flight[V]) (V, error)) (V, error) {
+	f.mutex.Lock()
+	key = f.canonical.canonical(key, true)
+	if l, ok := f.loading[key]; ok {
+		f.mutex.Unlock()
+		<-l.done
+		return l.value, l.err
+	}
+	l := &flight[V]{done: make(chan struct{}), err: ErrLoaderPanicked}
+	f.loading[key] = l
+	f.mutex.Unlock()
+	defer func() {
+		// waiting goroutines are released with ErrLoaderPanicked if the load panics
+		f.mutex.Lock()
+		delete(f.loading, key)
+		f.canonical.remove(key)
+		f.mutex.Unlock()
+		close(l.done)
+	}()
+	l.value, l.err = load(l)
+	return l.value, l.err
+}
+
+// invalidate marks the load in progress for a key, if any, as stale.
+func (f *flights[K, V]) invalidate(key K) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if l, ok := f.loading[f.canonical.canonical(key, false)]; ok {
+		l.stale = true
+	}
+}
+
+// invalidateAll marks every load in progress as stale.
+func (f *flights[K, V]) invalidateAll() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, l := range f.loading {
+		l.stale = true
+	}
+}
+
+// stale reports whether the key of a load was put, removed or cleared since the load started.
+func (f *flights[K, V]) stale(l *flight[V]) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return l.stale
+}
+
+// A keyLock is the lock of a key, along with the number of goroutines holding or waiting for it.
+type keyLock struct {
+	mutex sync.Mutex
+	users int
+}
+
+// A keyLocks serializes changes to the same key. The lock of a key is only held in memory while
+// it is used.
+type keyLocks[K comparable] struct {
+	mutex     sync.Mutex
+	canonical *keySet[K]
+	locks     map[K]*keyLock
+}
+
+// lock locks a key and returns a function that unlocks it.
+func (l *keyLocks[K]) lock(key K) func() {
+	l.mutex.Lock()
+	key = l.canonical.canonical(key, true)
+	k, ok := l.locks[key]
+	if !ok {
+		k = &keyLock{}
+		l.locks[key] = k
+	}
+	k.users++
+	l.mutex.Unlock()
+	k.mutex.Lock()
+	return func() {
+		k.mutex.Unlock()
+		l.mutex.Lock()
+		if k.users--; k.users == 0 {
+			delete(l.locks, key)
+			l.canonical.remove(key)
+		}
+		l.mutex.Unlock()
+	}
+}
+
+// A write is a pending change to the backing store of a cache.
+type write[K comparable, V any] struct {
+	key    K
+	value  V
+	delete bool
+}
+
+// apply saves the change with the supplied writer.
+func (w write[K, V]) apply(writer Writer[K, V]) error {
+	if w.delete {
+		return writer.Delete(w.key)
+	}
+	return writer.Write(w.key, w.value)
+}
+
+// A writeBehind saves changes to a backing store in the background, in the order they were made.
+type writeBehind[K comparable, V any] struct {
+	*worker[write[K, V]]
+	mutex sync.Mutex
+	errs  []error
+}
+
+// newWriteBehind initializes a write-behind for the supplied writer and starts its goroutine.
+func newWriteBehind[K comparable, V any](writer Writer[K, V]) *writeBehind[K, V] {
+	w := &writeBehind[K, V]{}
+	w.worker = newWorker(func(change write[K, V]) {
+		if err := change.apply(writer); err != nil {
+			w.mutex.Lock()
+			w.errs = append(w.errs, err)
+			w.mutex.Unlock()
+		}
+	})
+	return w
+}
+
+// flush waits until there are no pending changes and returns the errors of failed changes.
+func (w *writeBehind[K, V]) flush() error {
+	w.wait()
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	err := errors.Join(w.errs...)
+	w.errs = nil
+	return err
+}
+
+// loadingCache adds loading and writing to a concurrent cache.
+type loadingCache[K comparable, V any] struct {
+	*concurrentCache[K, V]
+	loader  Loader[K, V]
+	writer  Writer[K, V]
+	behind  *writeBehind[K, V]
+	flights flights[K, V]
+	locks   keyLocks[K]
+}
+
+// save saves a change to the backing store, or queues it with write-behind. Changes are saved at
+// once after the cache is closed.
+func (c *loadingCache[K, V]) save(change write[K, V]) error {
+	if c.behind != nil && c.behind.enqueue(change) || c.writer == nil {
+		return nil
+	}
+	return change.apply(c.writer)
+}
+
+// record records a load that started at the supplied time.
+func (c *loadingCache[K, V]) record(start time.Time, err error) {
+	if c.recorder != nil {
+		c.recorder.RecordLoad(time.Since(start), err)
+	}
+}
+
+// Close stops the janitor of the cache, and waits for pending writes to the backing store. The
+// errors of those writes are returned by the next call to Flush.
+func (c *loadingCache[K, V]) Close() {
+	c.concurrentCache.Close()
+	if c.behind != nil {
+		c.behind.close()
+	}
+}
+
+func (c *loadingCache[K, V]) Delete(key K) error {
+	defer c.locks.lock(key)()
+	if err := c.save(write[K, V]{key: key, delete: true}); err != nil {
+		return err
+	}
+	c.Remove(key)
+	return nil
+}
+
+func (c *loadingCache[K, V]) Flush() error {
+	if c.behind == nil {
+		return nil
+	}
+	return c.behind.flush()
+}
+
+// Get retrieves a value from the cache, loading it with the configured loader if it is missing.
+// Returns gollections.ErrNoSuchElement if the value is missing and there is no loader.
+func (c *loadingCache[K, V]) Get(key K) (V, error) {
+	if c.loader == nil {
+		return c.GetIfPresent(key)
+	}
+	return c.GetOrLoad(key, c.loader.Load)
+}
+
+func (c *loadingCache[K, V]) GetAll(keys []K) (map[K]V, error) {
+	values := make(map[K]V, len(keys))
+	var missing []K
+	for _, key := range keys {
+		if value, err := c.concurrentCache.Get(key); err == nil {
+			values[key] = value
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 || c.loader == nil {
+		return values, nil
+	}
+	start := time.Now()
+	loaded, err := c.loader.LoadAll(missing)
+	c.record(start, err)
+	for key, value := range loaded {
+		// a value added while loading takes precedence over the loaded value
+		if held, ok, _ := c.PutIfAbsent(key, value); ok {
+			value = held
+		}
+		values[key] = value
+	}
+	return values, err
+}
+
+func (c *loadingCache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	if value, err := c.concurrentCache.Get(key); err == nil {
+		return value, nil
+	}
+	return c.flights.do(key, func(l *flight[V]) (V, error) {
+		// the value may have been loaded since the cache was checked
+		if value, err := c.get(key); err == nil {
+			return value, nil
+		}
+		start := time.Now()
+		value, err := loader(key)
+		c.record(start, err)
+		if err != nil {
+			return value, err
+		}
+		// a value added while loading takes precedence over the loaded value, which is not added
+		// at all if the key was put, removed or cleared while loading
+		_, _, err = c.compute(key, func(current V, ok bool) (V, outcome, error) {
+			if ok {
+				value = current
+				return current, keep, nil
+			}
+			if c.flights.stale(l) {
+				return current, keep, nil
+			}
+			return value, set, nil
+		})
+		return value, err
+	})
+}
+
+// Put adds or updates a value, and stops a load of the key in progress from adding its value.
+func (c *loadingCache[K, V]) Put(key K, value V) error {
+	return c.PutWithTTL(key, value, c.ttl)
+}
+
+func (c *loadingCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	c.flights.invalidate(key)
+	return c.concurrentCache.PutWithTTL(key, value, ttl)
+}
+
+// Remove removes a value, and stops a load of the key in progress from adding its value.
+func (c *loadingCache[K, V]) Remove(key K) {
+	c.flights.invalidate(key)
+	c.concurrentCache.Remove(key)
+}
+
+// Clear removes every value, and stops the loads in progress from adding their values.
+func (c *loadingCache[K, V]) Clear() {
+	c.flights.invalidateAll()
+	c.concurrentCache.Clear()
+}
+
+func (c *loadingCache[K, V]) Write(key K, value V) error {
+	defer c.locks.lock(key)()
+	if err := c.save(write[K, V]{key: key, value: value}); err != nil {
+		return err
+	}
+	return c.Put(key, value)
+}
+
+// NewLoadingCacheOf initializes a concurrent cache that loads missing values with the supplied
+// loader. The loader may be nil if values are only loaded by GetOrLoad. Entries written by Write
+// and Delete are saved to the writer of WithWriter or WithWriteBehind, while Put, Remove and the
+// other methods of Cache only change the cache. The cache must be closed if it has a janitor or
+// writes behind.
+func NewLoadingCacheOf[K comparable, V any](maxSize int, loader Loader[K, V], options ...Option[K, V]) LoadingCacheOf[K, V] {
+	o := newOptions(options)
+	c := &loadingCache[K, V]{
+		concurrentCache: newConcurrentCache(maxSize, o),
+		loader:          loader,
+		writer:          o.writer,
+		flights:         flights[K, V]{canonical: newKeySet(o.keyEquality), loading: map[K]*flight[V]{}},
+		locks:           keyLocks[K]{canonical: newKeySet(o.keyEquality), locks: map[K]*keyLock{}},
+	}
+	// compound operations that change a key stop a load of it in progress from adding its value
+	c.compound.compute = func(key K, computation computation[V]) (V, bool, error) {
+		c.flights.invalidate(key)
+		return c.concurrentCache.compute(key, computation)
+	}
+	if o.writer != nil && o.writeBehind {
+		c.behind = newWriteBehind(o.writer)
+	}
+	return c
+}
@@ -0,0 +1,408 @@
+package cache_test
+
+import (
+	"errors"
+	"maps"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections"
+	"github.com/bsladewski/gollections/cache"
+)
+
+// A store is a backing store for tests that counts its loads and can fail writes.
+type store struct {
+	mutex  sync.Mutex
+	values map[string]int
+	loads  atomic.Int64
+	fail   error
+}
+
+func (s *store) Load(key string) (int, error) {
+	s.loads.Add(1)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.values[key]
+	if !ok {
+		return 0, gollections.ErrNoSuchElement
+	}
+	return value, nil
+}
+
+func (s *store) LoadAll(keys []string) (map[string]int, error) {
+	return cache.LoaderFunc[string, int](s.Load).LoadAll(keys)
+}
+
+func (s *store) Write(key string, value int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.fail != nil {
+		return s.fail
+	}
+	s.values[key] = value
+	return nil
+}
+
+func (s *store) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.fail != nil {
+		return s.fail
+	}
+	delete(s.values, key)
+	return nil
+}
+
+// A hookedStore is a backing store that calls a function after each value it saves.
+type hookedStore struct {
+	*store
+	saved func(value int)
+}
+
+func (s hookedStore) Write(key string, value int) error {
+	defer s.saved(value)
+	return s.store.Write(key, value)
+}
+
+// get gets the value of a key held by the store.
+func (s *store) get(key string) (int, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// TestLoadingCache tests that a loading cache loads missing values once and does not cache
+// errors.
+func TestLoadingCache(t *testing.T) {
+	s := &store{values: map[string]int{"a": 1, "b": 2}}
+	c := cache.NewLoadingCacheOf[string, int](10, s)
+	defer c.Close()
+	for i := 0; i < 3; i++ {
+		if got, err := c.Get("a"); err != nil || got != 1 {
+			t.Fatalf("expected 1, got %d, err: %v", got, err)
+		}
+	}
+	if _, err := c.Get("missing"); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if _, err := c.Get("missing"); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	// GetIfPresent does not load, even through the interface of a cache that does not load
+	var plain cache.CacheOf[string, int] = c
+	if _, err := plain.GetIfPresent("b"); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if loads := s.loads.Load(); loads != 3 {
+		t.Fatalf("expected 3 loads, got %d", loads)
+	}
+	failure := errors.New("failure")
+	if _, err := c.GetOrLoad("c", func(string) (int, error) { return 0, failure }); err != failure {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if got, err := c.GetOrLoad("c", func(string) (int, error) { return 3, nil }); err != nil || got != 3 {
+		t.Fatalf("expected 3, got %d, err: %v", got, err)
+	}
+	// a cache without a loader only loads through GetOrLoad
+	c = cache.NewLoadingCacheOf[string, int](10, nil)
+	if _, err := c.Get("a"); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+}
+
+// TestLoadingCacheConcurrentLoad tests that goroutines missing the same key at once share a
+// single load of its value.
+func TestLoadingCacheConcurrentLoad(t *testing.T) {
+	const goroutines = 16
+	var loads atomic.Int64
+	release := make(chan struct{})
+	c := cache.NewLoadingCacheOf[string, int](10, cache.LoaderFunc[string, int](func(key string) (int, error) {
+		loads.Add(1)
+		<-release
+		return len(key), nil
+	}))
+	var started, wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			if got, err := c.Get("key"); err != nil || got != 3 {
+				t.Errorf("expected 3, got %d, err: %v", got, err)
+			}
+		}()
+	}
+	started.Wait()
+	close(release)
+	wg.Wait()
+	// goroutines that miss while a load is in progress wait for it
+	loads.Store(0)
+	loading, release := make(chan struct{}), make(chan struct{})
+	go c.GetOrLoad("other", func(string) (int, error) {
+		close(loading)
+		<-release
+		return 5, nil
+	})
+	<-loading
+	done := make(chan int)
+	go func() {
+		value, _ := c.GetOrLoad("other", func(string) (int, error) {
+			loads.Add(1)
+			return 0, nil
+		})
+		done <- value
+	}()
+	close(release)
+	if got := <-done; got != 5 || loads.Load() != 0 {
+		t.Fatalf("expected the shared value 5 and no second load, got %d and %d loads", got, loads.Load())
+	}
+}
+
+// TestLoadingCacheLoadOrdering tests that a load does not overwrite a key written, deleted, put,
+// removed or cleared while the load is in progress.
+func TestLoadingCacheLoadOrdering(t *testing.T) {
+	s := &store{values: map[string]int{"a": 1, "b": 1, "c": 1, "d": 1, "e": 1, "f": 1, "g": 1}}
+	c := cache.NewLoadingCacheOf(10, s, cache.WithWriter[string, int](s))
+	// load starts a load of a key that completes once the returned function is called
+	load := func(key string) func() int {
+		loading, release, done := make(chan struct{}), make(chan struct{}), make(chan int)
+		go func() {
+			value, _ := c.GetOrLoad(key, func(key string) (int, error) {
+				value, err := s.Load(key)
+				close(loading)
+				<-release
+				return value, err
+			})
+			done <- value
+		}()
+		<-loading
+		return func() int {
+			close(release)
+			return <-done
+		}
+	}
+	wait := load("a")
+	if err := c.Write("a", 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(); got != 2 {
+		t.Fatalf("expected the written value 2, got %d", got)
+	}
+	if got, err := c.Peek("a"); err != nil || got != 2 {
+		t.Fatalf("expected the written value 2 to be kept, got %d, err: %v", got, err)
+	}
+	wait = load("b")
+	if err := c.Delete("b"); err != nil {
+		t.Fatal(err)
+	}
+	wait()
+	if c.Contains("b") {
+		t.Fatal("expected the deleted key not to be added by the load")
+	}
+	wait = load("c")
+	c.Write("c", 2)
+	c.Remove("c")
+	wait()
+	if c.Contains("c") {
+		t.Fatal("expected the value loaded before the write not to be added")
+	}
+	wait = load("d")
+	c.Put("d", 2)
+	if got := wait(); got != 2 {
+		t.Fatalf("expected the put value 2, got %d", got)
+	}
+	if got, err := c.Peek("d"); err != nil || got != 2 {
+		t.Fatalf("expected the put value 2 to be kept, got %d, err: %v", got, err)
+	}
+	wait = load("e")
+	c.Put("e", 2)
+	c.Remove("e")
+	wait()
+	if c.Contains("e") {
+		t.Fatal("expected the removed key not to be added by the load")
+	}
+	wait = load("f")
+	c.Compute("f", func(key string, value int, ok bool) (int, bool) { return 2, true })
+	c.Remove("f")
+	wait()
+	if c.Contains("f") {
+		t.Fatal("expected the value loaded before the computation not to be added")
+	}
+	wait = load("g")
+	c.Put("g", 2)
+	c.Clear()
+	wait()
+	if c.Contains("g") {
+		t.Fatal("expected the value loaded before the cache was cleared not to be added")
+	}
+}
+
+// TestLoadingCacheTooHeavy tests that a loaded value too heavy to be added is returned with
+// ErrTooHeavy.
+func TestLoadingCacheTooHeavy(t *testing.T) {
+	c := cache.NewLoadingCacheOf[string, int](0, nil,
+		cache.WithWeigher(func(key string, value int) int64 { return int64(value) }),
+		cache.WithMaxWeight[string, int](5))
+	value, err := c.GetOrLoad("a", func(string) (int, error) { return 10, nil })
+	if !errors.Is(err, cache.ErrTooHeavy) || value != 10 {
+		t.Fatalf("expected 10 with too heavy error, got %d, err: %v", value, err)
+	}
+	if c.Contains("a") {
+		t.Fatal("expected the heavy value not to be added")
+	}
+	if value, err := c.GetOrLoad("b", func(string) (int, error) { return 3, nil }); err != nil || value != 3 {
+		t.Fatalf("expected 3, got %d, err: %v", value, err)
+	}
+}
+
+// TestLoadingCacheConcurrentWrite tests that concurrent writes of the same key leave the same
+// value in the cache and the backing store.
+func TestLoadingCacheConcurrentWrite(t *testing.T) {
+	s := &store{values: map[string]int{}}
+	second := make(chan struct{})
+	// the first write waits for the second write after it is saved, unless writes are serialized
+	hook := func(value int) {
+		if value == 1 {
+			select {
+			case <-second:
+			case <-time.After(10 * time.Millisecond):
+			}
+		} else {
+			close(second)
+		}
+	}
+	c := cache.NewLoadingCacheOf(10, s, cache.WithWriter[string, int](hookedStore{s, hook}))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.Write("a", 1)
+	}()
+	for {
+		if _, ok := s.get("a"); ok {
+			break
+		}
+		runtime.Gosched()
+	}
+	c.Write("a", 2)
+	wg.Wait()
+	saved, _ := s.get("a")
+	if cached, err := c.Peek("a"); err != nil || saved != cached {
+		t.Fatalf("expected the same value, got %d saved and %d cached, err: %v", saved, cached, err)
+	}
+}
+
+// TestLoadingCachePanic tests that a load that panics does not prevent later loads.
+func TestLoadingCachePanic(t *testing.T) {
+	c := cache.NewLoadingCacheOf[string, int](10, nil)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the panic of the loader")
+			}
+		}()
+		c.GetOrLoad("a", func(string) (int, error) { panic("failure") })
+	}()
+	if got, err := c.GetOrLoad("a", func(string) (int, error) { return 1, nil }); err != nil || got != 1 {
+		t.Fatalf("expected 1, got %d, err: %v", got, err)
+	}
+}
+
+// TestLoadingCacheGetAll tests loading several values at once.
+func TestLoadingCacheGetAll(t *testing.T) {
+	s := &store{values: map[string]int{"a": 1, "b": 2, "c": 3}}
+	c := cache.NewLoadingCacheOf[string, int](10, s)
+	c.Put("a", 10)
+	got, err := c.GetAll([]string{"a", "b", "c", "d"})
+	if expected := map[string]int{"a": 10, "b": 2, "c": 3}; err != nil || !maps.Equal(got, expected) {
+		t.Fatalf("expected %v, got %v, err: %v", expected, got, err)
+	}
+	if loads := s.loads.Load(); loads != 3 {
+		t.Fatalf("expected 3 loads, got %d", loads)
+	}
+	if value, err := c.Get("b"); err != nil || value != 2 || s.loads.Load() != 3 {
+		t.Fatalf("expected 2 to be cached, got %d, err: %v", value, err)
+	}
+}
+
+// TestLoadingCacheWriter tests that writes and deletes are saved to the backing store before the
+// cache is changed.
+func TestLoadingCacheWriter(t *testing.T) {
+	s := &store{values: map[string]int{}}
+	c := cache.NewLoadingCacheOf(10, s, cache.WithWriter[string, int](s))
+	if err := c.Write("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := s.get("a"); !ok || value != 1 {
+		t.Fatalf("expected 1 to be saved, got %d", value)
+	}
+	c.Put("b", 2)
+	if _, ok := s.get("b"); ok {
+		t.Fatal("expected put not to be saved")
+	}
+	s.fail = errors.New("failure")
+	if err := c.Write("a", 3); err != s.fail {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if err := c.Delete("a"); err != s.fail {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if got, err := c.Get("a"); err != nil || got != 1 {
+		t.Fatalf("expected the cache to be unchanged, got %d, err: %v", got, err)
+	}
+	s.fail = nil
+	if err := c.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.get("a"); ok || c.Size() != 1 {
+		t.Fatalf("expected a to be deleted, got size %d", c.Size())
+	}
+}
+
+// TestLoadingCacheWriteBehind tests that writes are saved in the background in order, and that
+// errors are returned by Flush.
+func TestLoadingCacheWriteBehind(t *testing.T) {
+	s := &store{values: map[string]int{}}
+	c := cache.NewLoadingCacheOf(10, nil, cache.WithWriteBehind[string, int](s))
+	for i := 0; i < 100; i++ {
+		if err := c.Write("a", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Write("b", 1)
+	c.Delete("b")
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := s.get("a"); !ok || value != 99 {
+		t.Fatalf("expected 99 to be saved, got %d", value)
+	}
+	if _, ok := s.get("b"); ok {
+		t.Fatal("expected b to be deleted")
+	}
+	failure := errors.New("failure")
+	s.mutex.Lock()
+	s.fail = failure
+	s.mutex.Unlock()
+	c.Write("c", 1)
+	c.Write("d", 2)
+	if got, err := c.Get("c"); err != nil || got != 1 {
+		t.Fatalf("expected 1 to be cached at once, got %d, err: %v", got, err)
+	}
+	if err := c.Flush(); !errors.Is(err, failure) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatalf("expected errors to be cleared, got %v", err)
+	}
+	// writes are saved at once after the cache is closed
+	c.Close()
+	if err := c.Write("e", 3); err != failure {
+		t.Fatalf("expected failure, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/bsladewski/gollections"
+)
+
+// An Option configures a cache when it is initialized.
+type Option[K comparable, V any] func(*options[K, V])
+
+// options holds the configuration of a cache.
+type options[K comparable, V any] struct {
+	keyEquality gollections.Hasher[K]
+	shards      int
+	newPolicy   func() EvictionPolicy[K]
+	ttl         time.Duration
+	clock       Clock
+	// janitorInterval is the time between removals of expired entries by a janitor, or zero if
+	// the cache has no janitor
+	janitorInterval time.Duration
+	writer          Writer[K, V]
+	writeBehind     bool
+	listener        func(K, V, RemovalCause)
+	asyncListener   bool
+	stats           bool
+	recorder        StatsRecorder
+	weigher         Weigher[K, V]
+	maxWeight       int64
+}
+
+// defaultShards is the number of shards of a concurrent cache unless WithShards is used.
+const defaultShards = 16
+
+// WithKeyEquality sets the strategy used to decide whether two keys refer to the same entry.
+// By default keys are compared using ==.
+func WithKeyEquality[K comparable, V any](equality gollections.Hasher[K]) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.keyEquality = equality
+	}
+}
+
+// WithShards sets the number of shards a concurrent cache splits its entries between. Goroutines
+// only wait for each other when they use keys of the same shard, or when the cache evicts
+// entries. A number less than one is treated as one. Has no effect on a cache that is not
+// concurrent.
+func WithShards[K comparable, V any](shards int) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.shards = shards
+	}
+}
+
+// WithEvictionPolicy sets the function used to initialize the policy that selects the entries a
+// cache evicts when it is full, such as NewARCPolicy or NewTinyLFUPolicy. Each cache initializes
+// its own policy. By default the least recently used entry is evicted. Caches with any other
+// policy also record when each key was last used, so that Keys, Values and Entries iterate from
+// least to most recently used. This costs a map entry holding the key and a counter per entry of
+// the cache, and each iteration sorts the keys.
+func WithEvictionPolicy[K comparable, V any](newPolicy func() EvictionPolicy[K]) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.newPolicy = newPolicy
+	}
+}
+
+// WithWeigher sets the weigher that gets the weight of each entry added to a cache, so that the
+// cache can be limited by the total weight of its entries, see WithMaxWeight. By default each entry
+// weighs one.
+func WithWeigher[K comparable, V any](weigher Weigher[K, V]) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.weigher = weigher
+	}
+}
+
+// WithMaxWeight sets the maximum total weight of the entries in a cache. The cache evicts entries
+// when it is heavier than the maximum weight or holds more than its maximum number of entries, so
+// a cache limited only by weight is initialized with a maximum size of zero. Entries heavier than
+// the maximum weight are rejected with ErrTooHeavy.
+func WithMaxWeight[K comparable, V any](maxWeight int64) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.maxWeight = maxWeight
+	}
+}
+
+// WithTTL sets the time to live of entries added by Put, after which they expire. A time to live
+// less than or equal to zero, the default, means entries never expire.
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.ttl = ttl
+	}
+}
+
+// WithClock sets the clock used to expire entries. By default the cache uses SystemClock.
+func WithClock[K comparable, V any](clock Clock) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.clock = clock
+	}
+}
+
+// WithJanitor starts a goroutine that removes expired entries from a concurrent cache each time
+// the supplied interval passes, until the cache is closed. Without a janitor, expired entries are
+// only removed when they are read or evicted. Has no effect on a cache that is not concurrent,
+// which must not be used by more than one goroutine at once.
+func WithJanitor[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.janitorInterval = interval
+	}
+}
+
+// WithWriter sets the writer that a loading cache saves entries to when they are written or
+// deleted. Entries are saved before the cache is changed, and are not changed in the cache if they
+// cannot be saved. Has no effect on a cache that is not a loading cache.
+func WithWriter[K comparable, V any](writer Writer[K, V]) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.writer = writer
+		o.writeBehind = false
+	}
+}
+
+// WithWriteBehind sets the writer that a loading cache saves entries to when they are written or
+// deleted. The cache is changed at once and entries are saved by a goroutine in the order they
+// were written, so that writing to the cache does not wait for the writer. Errors of the writer
+// are returned by Flush. Has no effect on a cache that is not a loading cache.
+func WithWriteBehind[K comparable, V any](writer Writer[K, V]) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.writer = writer
+		o.writeBehind = true
+	}
+}
+
+// OnEvict sets a function that is called with each entry removed from a cache and the cause of
+// its removal, such as to release resources held by the value. The function is called on the
+// goroutine that changed the cache, after the change is complete and without holding any lock of
+// the cache, so it may use the cache.
+func OnEvict[K comparable, V any](listener func(key K, value V, cause RemovalCause)) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.listener = listener
+		o.asyncListener = false
+	}
+}
+
+// OnEvictAsync sets a function that is called with each entry removed from a cache and the cause
+// of its removal. The function is called by a goroutine of the cache in the order entries were
+// removed, so that changing the cache does not wait for the function. The cache must be closed to
+// stop the goroutine.
+func OnEvictAsync[K comparable, V any](listener func(key K, value V, cause RemovalCause)) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.listener = listener
+		o.asyncListener = true
+	}
+}
+
+// WithStats makes a cache count how it is used, so that Stats gets a snapshot of the counts. By
+// default caches do not count, which has no cost.
+func WithStats[K comparable, V any]() Option[K, V] {
+	return func(o *options[K, V]) {
+		o.stats = true
+	}
+}
+
+// WithStatsRecorder sets a recorder that a cache tells how it is used, such as to export metrics to
+// a monitoring system. The recorder does not affect the snapshot of Stats.
+func WithStatsRecorder[K comparable, V any](recorder StatsRecorder) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.recorder = recorder
+	}
+}
+
+// newOptions applies the supplied options to the default configuration.
+func newOptions[K comparable, V any](opts []Option[K, V]) options[K, V] {
+	o := options[K, V]{shards: defaultShards, newPolicy: NewLRUPolicy[K], clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
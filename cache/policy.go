@@ -0,0 +1,262 @@
+package cache
+
+import "math/rand/v2"
+
+// An EvictionPolicy decides which entry a cache evicts when it holds too many entries. A policy
+// tracks the keys held by the cache and is informed by the cache of every change. A policy is used
+// by a single cache, which serializes all calls to it.
+type EvictionPolicy[K comparable] interface {
+	// Access records that a key held by the cache was read or updated. Keys that are not held by
+	// the cache are ignored.
+	Access(key K)
+	// Add records that a key was added to the cache.
+	Add(key K)
+	// Clear forgets all keys.
+	Clear()
+	// Evict selects a key to evict from the cache and forgets it. Returns false if the policy
+	// holds no keys.
+	Evict() (K, bool)
+	// Remove forgets a key that was removed from the cache. Keys that are not held by the cache
+	// are ignored.
+	Remove(key K)
+	// SetCapacity sets the maximum number of entries of the cache, which policies use to size
+	// their internal structures. A capacity less than one means the cache is unbounded.
+	SetCapacity(capacity int)
+}
+
+// lruPolicy evicts the least recently used key.
+type lruPolicy[K comparable] struct {
+	nodes map[K]*node[K]
+	order keyList[K]
+}
+
+func (p *lruPolicy[K]) Access(key K) {
+	if n, ok := p.nodes[key]; ok {
+		p.order.moveToBack(n)
+	}
+}
+
+func (p *lruPolicy[K]) Add(key K) {
+	n := &node[K]{key: key}
+	p.nodes[key] = n
+	p.order.pushBack(n)
+}
+
+func (p *lruPolicy[K]) Clear() {
+	p.nodes = map[K]*node[K]{}
+	p.order.clear()
+}
+
+func (p *lruPolicy[K]) Evict() (K, bool) {
+	n := p.order.popFront()
+	if n == nil {
+		var zero K
+		return zero, false
+	}
+	delete(p.nodes, n.key)
+	return n.key, true
+}
+
+func (p *lruPolicy[K]) Remove(key K) {
+	if n, ok := p.nodes[key]; ok {
+		p.order.remove(n)
+		delete(p.nodes, key)
+	}
+}
+
+func (p *lruPolicy[K]) SetCapacity(capacity int) {}
+
+// NewLRUPolicy initializes a policy that evicts the least recently used key. This is the policy
+// of a cache unless WithEvictionPolicy is used.
+func NewLRUPolicy[K comparable]() EvictionPolicy[K] {
+	return &lruPolicy[K]{nodes: map[K]*node[K]{}}
+}
+
+// fifoPolicy evicts the key that was added first.
+type fifoPolicy[K comparable] struct {
+	lruPolicy[K]
+}
+
+func (p *fifoPolicy[K]) Access(key K) {}
+
+// NewFIFOPolicy initializes a policy that evicts the key that was added first, regardless of how
+// it has been used since.
+func NewFIFOPolicy[K comparable]() EvictionPolicy[K] {
+	return &fifoPolicy[K]{lruPolicy: lruPolicy[K]{nodes: map[K]*node[K]{}}}
+}
+
+// randomPolicy evicts a key chosen at random. Keys are held in a slice so that a key can be chosen
+// in O(1); removing a key moves the last key into its place.
+type randomPolicy[K comparable] struct {
+	indexes map[K]int
+	keys    []K
+	random  *rand.Rand
+}
+
+// removeAt forgets the key at the specified index of the slice.
+func (p *randomPolicy[K]) removeAt(index int) K {
+	key := p.keys[index]
+	last := len(p.keys) - 1
+	p.keys[index] = p.keys[last]
+	p.indexes[p.keys[index]] = index
+	var zero K
+	p.keys[last] = zero
+	p.keys = p.keys[:last]
+	delete(p.indexes, key)
+	return key
+}
+
+func (p *randomPolicy[K]) Access(key K) {}
+
+func (p *randomPolicy[K]) Add(key K) {
+	p.indexes[key] = len(p.keys)
+	p.keys = append(p.keys, key)
+}
+
+func (p *randomPolicy[K]) Clear() {
+	p.indexes = map[K]int{}
+	p.keys = nil
+}
+
+func (p *randomPolicy[K]) Evict() (K, bool) {
+	if len(p.keys) == 0 {
+		var zero K
+		return zero, false
+	}
+	intN := rand.IntN
+	if p.random != nil {
+		intN = p.random.IntN
+	}
+	return p.removeAt(intN(len(p.keys))), true
+}
+
+func (p *randomPolicy[K]) Remove(key K) {
+	if index, ok := p.indexes[key]; ok {
+		p.removeAt(index)
+	}
+}
+
+func (p *randomPolicy[K]) SetCapacity(capacity int) {}
+
+// NewRandomPolicy initializes a policy that evicts a key chosen at random using the global source
+// of randomness. The entry that was just added may be the one evicted.
+func NewRandomPolicy[K comparable]() EvictionPolicy[K] {
+	return NewRandomPolicyFrom[K](nil)
+}
+
+// NewRandomPolicyFrom initializes a policy that evicts a key chosen at random using the supplied
+// source of randomness, or the global source if it is nil.
+func NewRandomPolicyFrom[K comparable](r *rand.Rand) EvictionPolicy[K] {
+	return &randomPolicy[K]{indexes: map[K]int{}, random: r}
+}
+
+// lfuBucket holds the keys that have been used the same number of times, from least to most
+// recently used.
+type lfuBucket[K comparable] struct {
+	frequency int
+	keys      keyList[K]
+	previous  *lfuBucket[K]
+	next      *lfuBucket[K]
+}
+
+// lfuPolicy evicts the least frequently used key, and the least recently used of those if there
+// is a tie. Buckets of keys are linked in order of frequency, so that every operation is O(1).
+type lfuPolicy[K comparable] struct {
+	nodes   map[K]*node[K]
+	buckets map[*node[K]]*lfuBucket[K]
+	root    lfuBucket[K]
+	// added is the node of the key added last, which is not evicted before keys used as rarely
+	// so that new keys are not evicted as soon as they are added
+	added *node[K]
+}
+
+// link adds a node to the bucket of the specified frequency, creating the bucket after the
+// supplied bucket if it does not exist.
+func (p *lfuPolicy[K]) link(n *node[K], after *lfuBucket[K], frequency int) {
+	b := after.next
+	if b == &p.root || b.frequency != frequency {
+		b = &lfuBucket[K]{frequency: frequency, previous: after, next: after.next}
+		after.next.previous = b
+		after.next = b
+	}
+	b.keys.pushBack(n)
+	p.buckets[n] = b
+}
+
+// unlink removes a node from its bucket, removing the bucket if it becomes empty. Returns the
+// bucket that precedes the position of the node's bucket.
+func (p *lfuPolicy[K]) unlink(n *node[K]) *lfuBucket[K] {
+	b := p.buckets[n]
+	b.keys.remove(n)
+	delete(p.buckets, n)
+	if b.keys.length > 0 {
+		return b
+	}
+	b.previous.next = b.next
+	b.next.previous = b.previous
+	return b.previous
+}
+
+func (p *lfuPolicy[K]) Access(key K) {
+	n, ok := p.nodes[key]
+	if !ok {
+		return
+	}
+	frequency := p.buckets[n].frequency + 1
+	p.link(n, p.unlink(n), frequency)
+}
+
+func (p *lfuPolicy[K]) Add(key K) {
+	n := &node[K]{key: key}
+	p.nodes[key] = n
+	p.link(n, &p.root, 1)
+	p.added = n
+}
+
+func (p *lfuPolicy[K]) Clear() {
+	p.nodes = map[K]*node[K]{}
+	p.buckets = map[*node[K]]*lfuBucket[K]{}
+	p.root.next = &p.root
+	p.root.previous = &p.root
+	p.added = nil
+}
+
+func (p *lfuPolicy[K]) Evict() (K, bool) {
+	if len(p.nodes) == 0 {
+		var zero K
+		return zero, false
+	}
+	b := p.root.next
+	n := b.keys.front()
+	if n == p.added && b.keys.length == 1 && b.next != &p.root {
+		n = b.next.keys.front()
+	}
+	p.forget(n)
+	return n.key, true
+}
+
+// forget removes a node from its bucket and forgets its key.
+func (p *lfuPolicy[K]) forget(n *node[K]) {
+	p.unlink(n)
+	delete(p.nodes, n.key)
+	if n == p.added {
+		p.added = nil
+	}
+}
+
+func (p *lfuPolicy[K]) Remove(key K) {
+	if n, ok := p.nodes[key]; ok {
+		p.forget(n)
+	}
+}
+
+func (p *lfuPolicy[K]) SetCapacity(capacity int) {}
+
+// NewLFUPolicy initializes a policy that evicts the least frequently used key. Keys used equally
+// often are evicted in least recently used order. The key added last is only evicted if it is the
+// only key used least often, so that a new entry is not evicted by its own addition.
+func NewLFUPolicy[K comparable]() EvictionPolicy[K] {
+	p := &lfuPolicy[K]{}
+	p.Clear()
+	return p
+}
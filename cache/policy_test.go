@@ -0,0 +1,287 @@
+package cache_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/bsladewski/gollections/cache"
+)
+
+// policies are the built-in eviction policies by name, with the scan resistant policies last.
+var policies = []struct {
+	name      string
+	newPolicy func() cache.EvictionPolicy[int]
+}{
+	{"LRU", cache.NewLRUPolicy[int]},
+	{"FIFO", cache.NewFIFOPolicy[int]},
+	{"LFU", cache.NewLFUPolicy[int]},
+	{"Random", cache.NewRandomPolicy[int]},
+	{"2Q", cache.NewTwoQueuePolicy[int]},
+	{"ARC", cache.NewARCPolicy[int]},
+	{"TinyLFU", cache.NewTinyLFUPolicy[int]},
+}
+
+// evictAll evicts keys from a policy until it holds none.
+func evictAll(p cache.EvictionPolicy[int]) []int {
+	var keys []int
+	for {
+		key, ok := p.Evict()
+		if !ok {
+			return keys
+		}
+		keys = append(keys, key)
+	}
+}
+
+// TestEvictionPolicy tests that every built-in policy evicts each key it holds exactly once, and
+// never evicts removed keys.
+func TestEvictionPolicy(t *testing.T) {
+	for _, policy := range policies {
+		t.Run(policy.name, func(t *testing.T) {
+			p := policy.newPolicy()
+			p.SetCapacity(10)
+			for i := 0; i < 10; i++ {
+				p.Add(i)
+				p.Access(i / 2)
+			}
+			p.Access(100)
+			p.Remove(3)
+			p.Remove(100)
+			keys := evictAll(p)
+			slices.Sort(keys)
+			if expected := []int{0, 1, 2, 4, 5, 6, 7, 8, 9}; !slices.Equal(keys, expected) {
+				t.Fatalf("expected %v evicted, got %v", expected, keys)
+			}
+			p.Add(1)
+			p.Clear()
+			if keys := evictAll(p); len(keys) != 0 {
+				t.Fatalf("expected no keys after clear, got %v", keys)
+			}
+		})
+	}
+}
+
+// TestEvictionPolicyOrder tests the order in which simple policies evict keys.
+func TestEvictionPolicyOrder(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		newPolicy func() cache.EvictionPolicy[int]
+		expected  []int
+	}{
+		{"LRU", cache.NewLRUPolicy[int], []int{2, 4, 3, 1}},
+		{"FIFO", cache.NewFIFOPolicy[int], []int{1, 2, 3, 4}},
+		// LFU evicts the key added last only when no other key remains
+		{"LFU", cache.NewLFUPolicy[int], []int{2, 1, 3, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := test.newPolicy()
+			for i := 1; i <= 4; i++ {
+				p.Add(i)
+			}
+			p.Access(3)
+			p.Access(3)
+			p.Access(1)
+			if keys := evictAll(p); !slices.Equal(keys, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, keys)
+			}
+		})
+	}
+}
+
+// TestRandomPolicy tests that a random policy seeded with the same source evicts keys in the same
+// order.
+func TestRandomPolicy(t *testing.T) {
+	var orders [2][]int
+	for i := range orders {
+		p := cache.NewRandomPolicyFrom[int](rand.New(rand.NewPCG(1, 2)))
+		for key := 0; key < 20; key++ {
+			p.Add(key)
+		}
+		orders[i] = evictAll(p)
+	}
+	if !slices.Equal(orders[0], orders[1]) {
+		t.Fatalf("expected the same order, got %v and %v", orders[0], orders[1])
+	}
+}
+
+// TestEvictionPolicyCache tests caches using every built-in policy, checking that their size
+// matches the entries they hold and that they hold the most recently added entry.
+func TestEvictionPolicyCache(t *testing.T) {
+	for _, policy := range policies {
+		t.Run(policy.name, func(t *testing.T) {
+			for _, c := range []cache.CacheOf[int, int]{
+				cache.NewCacheOf(16, cache.WithEvictionPolicy[int, int](policy.newPolicy)),
+				cache.NewConcurrentCacheOf(16, cache.WithEvictionPolicy[int, int](policy.newPolicy)),
+			} {
+				r := rand.New(rand.NewPCG(1, 2))
+				for i := 0; i < 2000; i++ {
+					key := r.IntN(64)
+					switch n := r.IntN(100); {
+					case n < 50:
+						if value, err := c.Get(key); err == nil && value != key {
+							t.Fatalf("expected %d, got %d", key, value)
+						}
+					case n < 90:
+						c.Put(key, key)
+						// random eviction and the admission filter of TinyLFU may reject new entries
+						rejects := policy.name == "Random" || policy.name == "TinyLFU"
+						if value, err := c.Get(key); err != nil && !rejects {
+							t.Fatalf("expected %d to be held, got %d, err: %v", key, value, err)
+						}
+					case n < 98:
+						c.Remove(key)
+					default:
+						c.SetMaxSize(8 + r.IntN(16))
+					}
+					held := 0
+					for key := 0; key < 64; key++ {
+						if _, err := c.Get(key); err == nil {
+							held++
+						}
+					}
+					if size := c.Size(); size != held {
+						t.Fatalf("expected size %d, got %d", held, size)
+					}
+				}
+				c.SetMaxSize(4)
+				if size := c.Size(); size != 4 {
+					t.Fatalf("expected size 4, got %d", size)
+				}
+				c.Clear()
+				if size := c.Size(); size != 0 {
+					t.Fatalf("expected size 0, got %d", size)
+				}
+			}
+		})
+	}
+}
+
+// TestEvictionPolicyScan tests that scan resistant policies keep frequently used entries while a
+// long sequence of entries is read once. The cache first serves a workload in which a few hot keys
+// are read between short scans, so that every policy has seen the hot keys again after evicting
+// them, and then a scan ten times the size of the cache.
+func TestEvictionPolicyScan(t *testing.T) {
+	for _, policy := range policies[4:] {
+		t.Run(policy.name, func(t *testing.T) {
+			c := cache.NewCacheOf(100, cache.WithEvictionPolicy[int, int](policy.newPolicy))
+			// read reads a key, adding it if it is missed
+			read := func(key int) {
+				if _, err := c.Get(key); err != nil {
+					c.Put(key, key)
+				}
+			}
+			cold := 1000
+			for round := 0; round < 20; round++ {
+				for i := 0; i < 10; i++ {
+					read(i)
+				}
+				for i := 0; i < 30; i++ {
+					read(cold)
+					cold++
+				}
+			}
+			for i := 0; i < 1000; i++ {
+				read(cold)
+				cold++
+			}
+			for i := 0; i < 10; i++ {
+				if _, err := c.Get(i); err != nil {
+					t.Fatalf("expected %d to be held after a scan, got err: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+// A trace is a sequence of keys read from a cache.
+type trace struct {
+	name string
+	keys []int
+}
+
+// zipfTrace reads keys chosen from a Zipf distribution, so that few keys are read very often.
+func zipfTrace(n int) trace {
+	r := rand.New(rand.NewPCG(1, 2))
+	zipf := rand.NewZipf(r, 1.1, 1, 1<<16)
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = int(zipf.Uint64())
+	}
+	return trace{"zipf", keys}
+}
+
+// scanTrace interleaves reads of a small set of hot keys with scans of keys that are read once.
+func scanTrace(n int) trace {
+	r := rand.New(rand.NewPCG(1, 2))
+	keys := make([]int, n)
+	scan := 1 << 20
+	for i := range keys {
+		if i%1000 < 700 {
+			keys[i] = r.IntN(500)
+		} else {
+			keys[i] = scan
+			scan++
+		}
+	}
+	return trace{"scan", keys}
+}
+
+// loopTrace reads a sequence of keys slightly larger than the cache over and over.
+func loopTrace(n, size int) trace {
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = i % (size + size/4)
+	}
+	return trace{"loop", keys}
+}
+
+// replay reads the keys of a trace from a cache, adding the keys that are missed, and gets the
+// ratio of reads that were hits.
+func replay(c cache.CacheOf[int, int], t trace) float64 {
+	hits := 0
+	for _, key := range t.keys {
+		if _, err := c.Get(key); err == nil {
+			hits++
+		} else {
+			c.Put(key, key)
+		}
+	}
+	return float64(hits) / float64(len(t.keys))
+}
+
+// TestEvictionPolicyHitRatio tests that scan resistant policies hit more often than LRU when a
+// trace mixes hot keys with scans.
+func TestEvictionPolicyHitRatio(t *testing.T) {
+	scan := scanTrace(100_000)
+	ratios := map[string]float64{}
+	for _, policy := range policies {
+		ratios[policy.name] = replay(cache.NewCacheOf(1000, cache.WithEvictionPolicy[int, int](policy.newPolicy)), scan)
+	}
+	for _, policy := range policies[4:] {
+		name := policy.name
+		if ratios[name] <= ratios["LRU"] {
+			t.Errorf("expected %s to hit more often than LRU, got %.3f and %.3f", name, ratios[name], ratios["LRU"])
+		}
+	}
+}
+
+// BenchmarkEvictionPolicy replays traces against caches using every built-in policy and reports
+// the hit ratio of each, so that policies can be compared for a workload.
+func BenchmarkEvictionPolicy(b *testing.B) {
+	const size = 1000
+	for _, t := range []trace{zipfTrace(100_000), scanTrace(100_000), loopTrace(100_000, size)} {
+		for _, policy := range policies {
+			b.Run(fmt.Sprintf("%s/%s", t.name, policy.name), func(b *testing.B) {
+				var ratio float64
+				for i := 0; i < b.N; i++ {
+					c := cache.NewCacheOf(size, cache.WithEvictionPolicy[int, int](policy.newPolicy))
+					ratio = replay(c, t)
+				}
+				b.ReportMetric(ratio, "hit-ratio")
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(t.keys)), "ns/access")
+			})
+		}
+	}
+}
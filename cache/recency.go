@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/bsladewski/gollections"
+)
+
+// A keyOrder orders the keys of an eviction policy from least to most recently used.
+type keyOrder[K comparable] interface {
+	// keys gets the keys of the policy from least to most recently used.
+	keys() []K
+}
+
+// recencyPolicy tells an eviction policy of every change to a cache, and records when each key of
+// the cache was last used so that the keys can be ordered for iteration. Recording a counter per
+// key costs less memory than keeping the keys in a second list, at the cost of sorting the keys
+// each time they are ordered.
+type recencyPolicy[K comparable] struct {
+	EvictionPolicy[K]
+	used map[K]uint64
+	uses uint64
+}
+
+// use records that a key was used.
+func (p *recencyPolicy[K]) use(key K) {
+	p.uses++
+	p.used[key] = p.uses
+}
+
+func (p *recencyPolicy[K]) Access(key K) {
+	p.EvictionPolicy.Access(key)
+	if _, ok := p.used[key]; ok {
+		p.use(key)
+	}
+}
+
+func (p *recencyPolicy[K]) Add(key K) {
+	p.EvictionPolicy.Add(key)
+	p.use(key)
+}
+
+func (p *recencyPolicy[K]) Clear() {
+	p.EvictionPolicy.Clear()
+	p.used = map[K]uint64{}
+}
+
+func (p *recencyPolicy[K]) Evict() (K, bool) {
+	key, ok := p.EvictionPolicy.Evict()
+	if ok {
+		delete(p.used, key)
+	}
+	return key, ok
+}
+
+func (p *recencyPolicy[K]) Remove(key K) {
+	p.EvictionPolicy.Remove(key)
+	delete(p.used, key)
+}
+
+func (p *recencyPolicy[K]) keys() []K {
+	used := make([]gollections.Pair[K, uint64], 0, len(p.used))
+	for key, uses := range p.used {
+		used = append(used, gollections.Pair[K, uint64]{First: key, Second: uses})
+	}
+	slices.SortFunc(used, func(a, b gollections.Pair[K, uint64]) int {
+		return cmp.Compare(a.Second, b.Second)
+	})
+	keys := make([]K, len(used))
+	for i, u := range used {
+		keys[i] = u.First
+	}
+	return keys
+}
+
+// withRecency gets a policy that records the recency of keys as well as applying the supplied
+// policy, and the order of its keys. A least recently used policy already orders its keys, so it
+// is not wrapped.
+func withRecency[K comparable](policy EvictionPolicy[K]) (EvictionPolicy[K], keyOrder[K]) {
+	if p, ok := policy.(*lruPolicy[K]); ok {
+		return p, p
+	}
+	p := &recencyPolicy[K]{EvictionPolicy: policy, used: map[K]uint64{}}
+	return p, p
+}
+
+// keys gets the keys of the policy from least to most recently used.
+func (p *lruPolicy[K]) keys() []K {
+	keys := make([]K, 0, p.order.length)
+	for n := p.order.front(); n != nil && n != &p.order.root; n = n.next {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
@@ -0,0 +1,50 @@
+package cache_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/bsladewski/gollections/cache"
+)
+
+// TestCacheIterators tests that both kinds of cache iterate over their entries from least to most
+// recently used with every policy.
+func TestCacheIterators(t *testing.T) {
+	for _, policy := range policies {
+		t.Run(policy.name, func(t *testing.T) {
+			for _, c := range []cache.CacheOf[int, int]{
+				cache.NewCacheOf(10, cache.WithEvictionPolicy[int, int](policy.newPolicy)),
+				cache.NewConcurrentCacheOf(10, cache.WithEvictionPolicy[int, int](policy.newPolicy)),
+			} {
+				for i := 0; i < 4; i++ {
+					c.Put(i, -i)
+				}
+				c.Get(1)
+				c.Put(0, 0)
+				// peeking and iterating do not change the order
+				c.Peek(2)
+				c.Contains(2)
+				for range c.Entries() {
+				}
+				if keys, expected := slices.Collect(c.Keys()), []int{2, 3, 1, 0}; !slices.Equal(keys, expected) {
+					t.Fatalf("expected %v, got %v", expected, keys)
+				}
+				if values, expected := slices.Collect(c.Values()), []int{-2, -3, -1, 0}; !slices.Equal(values, expected) {
+					t.Fatalf("expected %v, got %v", expected, values)
+				}
+				if entries, expected := maps.Collect(c.Entries()), map[int]int{0: 0, 1: -1, 2: -2, 3: -3}; !maps.Equal(entries, expected) {
+					t.Fatalf("expected %v, got %v", expected, entries)
+				}
+				// the cache can be changed while iterating
+				for key := range c.Keys() {
+					c.Remove(key)
+					c.Get(key)
+				}
+				if size := c.Size(); size != 0 || len(slices.Collect(c.Keys())) != 0 {
+					t.Fatalf("expected the cache to be empty, got size %d", size)
+				}
+			}
+		})
+	}
+}
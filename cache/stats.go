@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// A StatsRecorder records how a cache is used, such as to export metrics to a monitoring system.
+// A recorder must be safe for concurrent use if the cache is concurrent.
+type StatsRecorder interface {
+	// RecordHit records that a value was found in the cache.
+	RecordHit()
+	// RecordMiss records that a value was not found in the cache.
+	RecordMiss()
+	// RecordLoad records that a loading cache loaded values, how long the load took and whether it
+	// failed.
+	RecordLoad(elapsed time.Duration, err error)
+	// RecordRemoval records that an entry was removed from the cache.
+	RecordRemoval(cause RemovalCause)
+}
+
+// Stats is a snapshot of how a cache has been used since it was initialized.
+type Stats struct {
+	// Hits is the number of values found in the cache.
+	Hits uint64
+	// Misses is the number of values not found in the cache.
+	Misses uint64
+	// Loads is the number of times a loading cache loaded values.
+	Loads uint64
+	// LoadErrors is the number of loads that failed.
+	LoadErrors uint64
+	// LoadTime is the total time spent loading values.
+	LoadTime time.Duration
+	// Evictions is the number of entries removed from the cache by each cause.
+	Evictions map[RemovalCause]uint64
+}
+
+// HitRatio gets the ratio of values found in the cache to values requested, or zero if no values
+// were requested.
+func (s Stats) HitRatio() float64 {
+	if requests := s.Hits + s.Misses; requests > 0 {
+		return float64(s.Hits) / float64(requests)
+	}
+	return 0
+}
+
+// AverageLoadTime gets the average time spent loading values, or zero if no values were loaded.
+func (s Stats) AverageLoadTime() time.Duration {
+	if s.Loads > 0 {
+		return s.LoadTime / time.Duration(s.Loads)
+	}
+	return 0
+}
+
+// causes is the number of removal causes.
+const causes = int(CauseCleared) + 1
+
+// A statsCounter counts the uses of a cache for its stats.
+type statsCounter struct {
+	hits       atomic.Uint64
+	misses     atomic.Uint64
+	loads      atomic.Uint64
+	loadErrors atomic.Uint64
+	loadTime   atomic.Int64
+	evictions  [causes]atomic.Uint64
+}
+
+func (s *statsCounter) RecordHit() {
+	s.hits.Add(1)
+}
+
+func (s *statsCounter) RecordMiss() {
+	s.misses.Add(1)
+}
+
+func (s *statsCounter) RecordLoad(elapsed time.Duration, err error) {
+	s.loads.Add(1)
+	if err != nil {
+		s.loadErrors.Add(1)
+	}
+	s.loadTime.Add(int64(elapsed))
+}
+
+func (s *statsCounter) RecordRemoval(cause RemovalCause) {
+	s.evictions[cause].Add(1)
+}
+
+// snapshot gets the current stats. Stats recorded at the same time may be partially included.
+func (s *statsCounter) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	stats := Stats{
+		Hits:       s.hits.Load(),
+		Misses:     s.misses.Load(),
+		Loads:      s.loads.Load(),
+		LoadErrors: s.loadErrors.Load(),
+		LoadTime:   time.Duration(s.loadTime.Load()),
+		Evictions:  make(map[RemovalCause]uint64, causes),
+	}
+	for cause := range s.evictions {
+		stats.Evictions[RemovalCause(cause)] = s.evictions[cause].Load()
+	}
+	return stats
+}
+
+// recorders records the uses of a cache with several recorders.
+type recorders []StatsRecorder
+
+func (r recorders) RecordHit() {
+	for _, recorder := range r {
+		recorder.RecordHit()
+	}
+}
+
+func (r recorders) RecordMiss() {
+	for _, recorder := range r {
+		recorder.RecordMiss()
+	}
+}
+
+func (r recorders) RecordLoad(elapsed time.Duration, err error) {
+	for _, recorder := range r {
+		recorder.RecordLoad(elapsed, err)
+	}
+}
+
+func (r recorders) RecordRemoval(cause RemovalCause) {
+	for _, recorder := range r {
+		recorder.RecordRemoval(cause)
+	}
+}
+
+// newRecorder initializes the stats counter and recorder of a cache. Both are nil if the cache
+// does not record stats, so that using the cache has no cost.
+func newRecorder[K comparable, V any](o options[K, V]) (*statsCounter, StatsRecorder) {
+	var counter *statsCounter
+	var r recorders
+	if o.stats {
+		counter = &statsCounter{}
+		r = append(r, counter)
+	}
+	if o.recorder != nil {
+		r = append(r, o.recorder)
+	}
+	switch len(r) {
+	case 0:
+		return nil, nil
+	case 1:
+		return counter, r[0]
+	}
+	return counter, r
+}
+
+// PublishStats publishes the stats of a cache as an expvar variable with the supplied name. Like
+// expvar.Publish, it panics if the name is already in use.
+func PublishStats[K comparable, V any](name string, c CacheOf[K, V]) {
+	expvar.Publish(name, expvar.Func(func() any {
+		stats := c.Stats()
+		return struct {
+			Stats
+			HitRatio        float64
+			AverageLoadTime time.Duration
+		}{stats, stats.HitRatio(), stats.AverageLoadTime()}
+	}))
+}
@@ -0,0 +1,131 @@
+package cache_test
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections/cache"
+)
+
+// TestCacheStats tests the stats of both kinds of cache.
+func TestCacheStats(t *testing.T) {
+	for _, c := range []cache.CacheOf[int, int]{
+		cache.NewCacheOf(2, cache.WithStats[int, int]()),
+		cache.NewConcurrentCacheOf(2, cache.WithStats[int, int]()),
+	} {
+		if ratio := c.Stats().HitRatio(); ratio != 0 {
+			t.Fatalf("expected hit ratio 0, got %f", ratio)
+		}
+		for i := 0; i < 4; i++ {
+			c.Put(i%3, i)
+		}
+		c.Put(0, 4)
+		c.Get(0)
+		c.Get(1)
+		c.Get(2)
+		c.Get(3)
+		c.Remove(2)
+		c.Clear()
+		stats := c.Stats()
+		if stats.Hits != 2 || stats.Misses != 2 || stats.HitRatio() != 0.5 {
+			t.Fatalf("expected 2 hits and 2 misses, got %+v", stats)
+		}
+		expected := map[cache.RemovalCause]uint64{
+			cache.CauseSize:     2,
+			cache.CauseExpired:  0,
+			cache.CauseExplicit: 1,
+			cache.CauseReplaced: 1,
+			cache.CauseCleared:  1,
+		}
+		for cause, n := range expected {
+			if stats.Evictions[cause] != n {
+				t.Fatalf("expected %d removals with cause %s, got %v", n, cause, stats.Evictions)
+			}
+		}
+	}
+	// stats are empty unless they are enabled
+	c := cache.NewCacheOf[int, int](2)
+	c.Get(0)
+	if stats := c.Stats(); stats.Misses != 0 || stats.Evictions != nil {
+		t.Fatalf("expected empty stats, got %+v", stats)
+	}
+}
+
+// TestLoadingCacheStats tests the load stats of a loading cache.
+func TestLoadingCacheStats(t *testing.T) {
+	failure := errors.New("failure")
+	c := cache.NewLoadingCacheOf(10, cache.LoaderFunc[int, int](func(key int) (int, error) {
+		time.Sleep(time.Millisecond)
+		if key < 0 {
+			return 0, failure
+		}
+		return key, nil
+	}), cache.WithStats[int, int]())
+	c.Get(1)
+	c.Get(1)
+	c.Get(-1)
+	c.GetAll([]int{1, 2, 3})
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 4 || stats.Loads != 3 || stats.LoadErrors != 1 {
+		t.Fatalf("expected 2 hits, 4 misses, 3 loads and 1 load error, got %+v", stats)
+	}
+	if stats.AverageLoadTime() < time.Millisecond {
+		t.Fatalf("expected an average load time of at least 1ms, got %v", stats.AverageLoadTime())
+	}
+}
+
+// A counter is a stats recorder that counts the calls of each method.
+type counter struct {
+	mutex sync.Mutex
+	calls map[string]int
+}
+
+func (c *counter) record(method string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls[method]++
+}
+
+func (c *counter) RecordHit()                       { c.record("hit") }
+func (c *counter) RecordMiss()                      { c.record("miss") }
+func (c *counter) RecordLoad(time.Duration, error)  { c.record("load") }
+func (c *counter) RecordRemoval(cache.RemovalCause) { c.record("removal") }
+
+// TestStatsRecorder tests that a recorder is told how a cache is used alongside its stats.
+func TestStatsRecorder(t *testing.T) {
+	r := &counter{calls: map[string]int{}}
+	c := cache.NewLoadingCacheOf(1, cache.LoaderFunc[int, int](func(key int) (int, error) {
+		return key, nil
+	}), cache.WithStatsRecorder[int, int](r), cache.WithStats[int, int]())
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+	if r.calls["hit"] != 1 || r.calls["miss"] != 2 || r.calls["load"] != 2 || r.calls["removal"] != 1 {
+		t.Fatalf("expected 1 hit, 2 misses, 2 loads and 1 removal, got %v", r.calls)
+	}
+	if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+		t.Fatalf("expected 1 hit and 2 misses, got %+v", stats)
+	}
+}
+
+// TestPublishStats tests publishing the stats of a cache as an expvar variable.
+func TestPublishStats(t *testing.T) {
+	c := cache.NewCacheOf(1, cache.WithStats[string, int]())
+	// names can only be published once, so each run of the test uses a new name
+	name := fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+	cache.PublishStats(name, c)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("b")
+	published := expvar.Get(name).String()
+	for _, expected := range []string{`"Hits":1`, `"HitRatio":1`, `"size":1`} {
+		if !strings.Contains(published, expected) {
+			t.Fatalf("expected %s to contain %s", published, expected)
+		}
+	}
+}
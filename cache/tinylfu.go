@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// sketchDepth is the number of rows of a count-min sketch.
+const sketchDepth = 4
+
+// sketchMaximum is the largest value of a counter of a count-min sketch. Counters saturate so
+// that they fit in four bits, as in the TinyLFU paper, although each is stored in a byte.
+const sketchMaximum = 15
+
+// A countMinSketch estimates how often keys have been seen using a fixed amount of memory. Each
+// key increments one counter in each row and its frequency is estimated by the smallest of those
+// counters. All counters are halved periodically so that the sketch favors recent history.
+type countMinSketch[K comparable] struct {
+	seed      maphash.Seed
+	counters  [sketchDepth][]uint8
+	shift     uint
+	additions int
+	period    int
+}
+
+// sketchMultipliers are the odd constants that hash a key to a counter in each row of a
+// count-min sketch. Each row uses its own multiplier so that two keys that share a counter in
+// one row are unlikely to share one in any other row.
+var sketchMultipliers = [sketchDepth]uint64{
+	0x9e3779b97f4a7c15, 0xc2b2ae3d27d4eb4f, 0x165667b19e3779f9, 0xd6e8feb86659fd93,
+}
+
+// sketchWidthFactor is the number of counters in each row of a count-min sketch per entry of the
+// cache. Fewer counters cause keys to share counters and their frequency to be overestimated.
+const sketchWidthFactor = 4
+
+// newCountMinSketch initializes a sketch sized for a cache of the supplied capacity. Counters are
+// halved after ten times the capacity of the cache has been added.
+func newCountMinSketch[K comparable](capacity int) *countMinSketch[K] {
+	capacity = max(capacity, 16)
+	width := 1 << bits.Len(uint(capacity*sketchWidthFactor-1))
+	s := &countMinSketch[K]{
+		seed:   maphash.MakeSeed(),
+		shift:  uint(64 - bits.TrailingZeros(uint(width))),
+		period: 10 * capacity,
+	}
+	for i := range s.counters {
+		s.counters[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// indexes gets the position of the counter of a key in each row.
+func (s *countMinSketch[K]) indexes(key K) [sketchDepth]uint64 {
+	hash := maphash.Comparable(s.seed, key)
+	var indexes [sketchDepth]uint64
+	for i := range indexes {
+		// multiply-shift hashing takes the position from the high bits of the product
+		indexes[i] = (hash * sketchMultipliers[i]) >> s.shift
+	}
+	return indexes
+}
+
+// increment records that a key has been seen.
+func (s *countMinSketch[K]) increment(key K) {
+	for row, index := range s.indexes(key) {
+		if s.counters[row][index] < sketchMaximum {
+			s.counters[row][index]++
+		}
+	}
+	s.additions++
+	if s.additions >= s.period {
+		s.age()
+	}
+}
+
+// estimate gets the estimated number of times a key has been seen.
+func (s *countMinSketch[K]) estimate(key K) uint8 {
+	estimate := uint8(sketchMaximum)
+	for row, index := range s.indexes(key) {
+		estimate = min(estimate, s.counters[row][index])
+	}
+	return estimate
+}
+
+// age halves all counters.
+func (s *countMinSketch[K]) age() {
+	for _, row := range s.counters {
+		for i := range row {
+			row[i] /= 2
+		}
+	}
+	s.additions /= 2
+}
+
+// Segments of the W-TinyLFU policy.
+const (
+	tinyLFUWindow uint8 = iota
+	tinyLFUProbation
+	tinyLFUProtected
+)
+
+// tinyLFUPolicy is the W-TinyLFU policy of Einziger, Friedman and Manes. New keys enter a small
+// LRU window. Keys leaving the window are admitted to the main segmented LRU only if the sketch
+// estimates that they are used more often than the key the main segment would evict. The main
+// segment keeps keys used more than once in a protected segment, and the rest in a probation
+// segment from which keys are evicted first.
+type tinyLFUPolicy[K comparable] struct {
+	nodes     map[K]*node[K]
+	lists     [3]keyList[K]
+	sketch    *countMinSketch[K]
+	capacity  int
+	window    int
+	protected int
+}
+
+// move unlinks a node from its list and links it to the end of another list.
+func (p *tinyLFUPolicy[K]) move(n *node[K], segment uint8) {
+	p.lists[n.segment].remove(n)
+	n.segment = segment
+	p.lists[segment].pushBack(n)
+}
+
+// evict forgets a node.
+func (p *tinyLFUPolicy[K]) evict(n *node[K]) (K, bool) {
+	p.lists[n.segment].remove(n)
+	delete(p.nodes, n.key)
+	return n.key, true
+}
+
+func (p *tinyLFUPolicy[K]) Access(key K) {
+	n, ok := p.nodes[key]
+	if !ok {
+		return
+	}
+	p.sketch.increment(key)
+	switch n.segment {
+	case tinyLFUWindow:
+		p.lists[tinyLFUWindow].moveToBack(n)
+	case tinyLFUProbation:
+		p.move(n, tinyLFUProtected)
+		// demote the least recently used protected key if the protected segment is full
+		if protected := &p.lists[tinyLFUProtected]; p.capacity > 0 && protected.length > p.protected {
+			p.move(protected.front(), tinyLFUProbation)
+		}
+	case tinyLFUProtected:
+		p.lists[tinyLFUProtected].moveToBack(n)
+	}
+}
+
+func (p *tinyLFUPolicy[K]) Add(key K) {
+	p.sketch.increment(key)
+	n := &node[K]{key: key, segment: tinyLFUWindow}
+	p.nodes[key] = n
+	window := &p.lists[tinyLFUWindow]
+	window.pushBack(n)
+	// keys leaving the window enter the main segment without competing while it has room
+	for window.length > p.window && (p.capacity <= 0 || len(p.nodes)-window.length < p.capacity-p.window) {
+		p.move(window.front(), tinyLFUProbation)
+	}
+}
+
+func (p *tinyLFUPolicy[K]) Clear() {
+	p.nodes = map[K]*node[K]{}
+	for i := range p.lists {
+		p.lists[i].clear()
+	}
+	p.sketch = newCountMinSketch[K](p.capacity)
+}
+
+func (p *tinyLFUPolicy[K]) Evict() (K, bool) {
+	window := &p.lists[tinyLFUWindow]
+	candidate := window.front()
+	victim := p.lists[tinyLFUProbation].front()
+	if victim == nil {
+		victim = p.lists[tinyLFUProtected].front()
+	}
+	switch {
+	case candidate == nil && victim == nil:
+		var zero K
+		return zero, false
+	case victim == nil:
+		return p.evict(candidate)
+	case candidate == nil || window.length <= p.window:
+		// the window is within its share, so the main segment is over its share
+		return p.evict(victim)
+	}
+	// the candidate leaving the window competes with the victim of the main segment
+	if p.sketch.estimate(candidate.key) > p.sketch.estimate(victim.key) {
+		p.move(candidate, tinyLFUProbation)
+		return p.evict(victim)
+	}
+	return p.evict(candidate)
+}
+
+func (p *tinyLFUPolicy[K]) Remove(key K) {
+	if n, ok := p.nodes[key]; ok {
+		p.evict(n)
+	}
+}
+
+func (p *tinyLFUPolicy[K]) SetCapacity(capacity int) {
+	if capacity == p.capacity {
+		return
+	}
+	p.capacity = capacity
+	p.window = max(capacity/100, 1)
+	p.protected = (capacity - p.window) * 4 / 5
+	p.sketch = newCountMinSketch[K](capacity)
+}
+
+// NewTinyLFUPolicy initializes a W-TinyLFU policy. One percent of the cache is an LRU window for
+// new keys, and a count-min sketch decides whether keys leaving the window are admitted to the
+// rest of the cache. The policy keeps frequently used keys through scans and bursts of new keys
+// while still admitting keys whose use increases.
+func NewTinyLFUPolicy[K comparable]() EvictionPolicy[K] {
+	p := &tinyLFUPolicy[K]{capacity: -1}
+	p.Clear()
+	p.SetCapacity(0)
+	return p
+}
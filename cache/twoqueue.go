@@ -0,0 +1,112 @@
+package cache
+
+// Segments of the 2Q policy.
+const (
+	twoQueueIn uint8 = iota
+	twoQueueOut
+	twoQueueMain
+)
+
+// twoQueuePolicy is the full 2Q policy of Johnson and Shasha. New keys enter a FIFO queue, keys
+// evicted from that queue are remembered in a ghost queue, and keys that are added again while
+// remembered enter the main LRU queue. Keys that are only used once therefore never displace keys
+// that are used repeatedly, which makes the policy resistant to scans.
+type twoQueuePolicy[K comparable] struct {
+	nodes    map[K]*node[K]
+	in       keyList[K]
+	out      keyList[K]
+	main     keyList[K]
+	capacity int
+}
+
+// list gets the list of the specified segment.
+func (p *twoQueuePolicy[K]) list(segment uint8) *keyList[K] {
+	switch segment {
+	case twoQueueIn:
+		return &p.in
+	case twoQueueOut:
+		return &p.out
+	}
+	return &p.main
+}
+
+// move unlinks a node from its segment and links it to the end of another segment.
+func (p *twoQueuePolicy[K]) move(n *node[K], segment uint8) {
+	p.list(n.segment).remove(n)
+	n.segment = segment
+	p.list(segment).pushBack(n)
+}
+
+// inCapacity gets the number of keys the FIFO queue holds before keys are evicted from it.
+func (p *twoQueuePolicy[K]) inCapacity() int {
+	return max(p.capacity/4, 1)
+}
+
+// outCapacity gets the number of evicted keys the ghost queue remembers.
+func (p *twoQueuePolicy[K]) outCapacity() int {
+	return max(p.capacity/2, 1)
+}
+
+func (p *twoQueuePolicy[K]) Access(key K) {
+	// keys in the FIFO queue are not promoted until they are seen again after eviction
+	if n, ok := p.nodes[key]; ok && n.segment == twoQueueMain {
+		p.main.moveToBack(n)
+	}
+}
+
+func (p *twoQueuePolicy[K]) Add(key K) {
+	if n, ok := p.nodes[key]; ok {
+		if n.segment == twoQueueOut {
+			p.move(n, twoQueueMain)
+		}
+		return
+	}
+	n := &node[K]{key: key, segment: twoQueueIn}
+	p.nodes[key] = n
+	p.in.pushBack(n)
+}
+
+func (p *twoQueuePolicy[K]) Clear() {
+	p.nodes = map[K]*node[K]{}
+	p.in.clear()
+	p.out.clear()
+	p.main.clear()
+}
+
+func (p *twoQueuePolicy[K]) Evict() (K, bool) {
+	if p.in.length > 0 && (p.in.length > p.inCapacity() || p.main.length == 0) {
+		n := p.in.front()
+		p.move(n, twoQueueOut)
+		for p.out.length > p.outCapacity() {
+			delete(p.nodes, p.out.popFront().key)
+		}
+		return n.key, true
+	}
+	n := p.main.popFront()
+	if n == nil {
+		var zero K
+		return zero, false
+	}
+	delete(p.nodes, n.key)
+	return n.key, true
+}
+
+func (p *twoQueuePolicy[K]) Remove(key K) {
+	if n, ok := p.nodes[key]; ok && n.segment != twoQueueOut {
+		p.list(n.segment).remove(n)
+		delete(p.nodes, key)
+	}
+}
+
+func (p *twoQueuePolicy[K]) SetCapacity(capacity int) {
+	p.capacity = capacity
+}
+
+// NewTwoQueuePolicy initializes a 2Q policy. A quarter of the cache holds keys that have been
+// seen once, and the keys of half as many entries as the cache holds are remembered after they
+// are evicted, so that they are kept longer when they are added again.
+func NewTwoQueuePolicy[K comparable]() EvictionPolicy[K] {
+	p := &twoQueuePolicy[K]{}
+	p.Clear()
+	return p
+}
@@ -0,0 +1,67 @@
+package cache
+
+import "fmt"
+
+// A Weigher gets the weight of an entry, such as the number of bytes held by its value. Weights
+// must not be negative, and must not change while the entry is held by a cache.
+type Weigher[K comparable, V any] func(key K, value V) int64
+
+// weigh gets the weight of an entry using the supplied weigher. Entries weigh one if there is no
+// weigher, so that the weight of a cache is its number of entries.
+func weigh[K comparable, V any](weigher Weigher[K, V], key K, value V) int64 {
+	if weigher == nil {
+		return 1
+	}
+	return weigher(key, value)
+}
+
+// limits holds the maximum number of entries and total weight of a cache, and tells the eviction
+// policy of the cache how many entries it can hold.
+type limits[K comparable] struct {
+	maxSize   int
+	maxWeight int64
+	policy    EvictionPolicy[K]
+	// recency orders the keys of the policy from least to most recently used
+	recency keyOrder[K]
+	// capacity is the capacity last set on the policy, or -1 if none has been set
+	capacity int
+}
+
+// newLimits initializes limits for the supplied policy that do not limit the cache.
+func newLimits[K comparable](policy EvictionPolicy[K]) limits[K] {
+	l := limits[K]{capacity: -1}
+	l.policy, l.recency = withRecency(policy)
+	return l
+}
+
+// exceeded reports whether a cache holding entries of the supplied number and total weight holds
+// too many entries.
+func (l *limits[K]) exceeded(size int, weight int64) bool {
+	return l.maxSize > 0 && size > l.maxSize || l.maxWeight > 0 && weight > l.maxWeight
+}
+
+// admit returns ErrTooHeavy if an entry of the supplied weight is heavier than the maximum weight.
+func (l *limits[K]) admit(weight int64) error {
+	if l.maxWeight > 0 && weight > l.maxWeight {
+		return fmt.Errorf("%w: weighs %d, the maximum weight is %d", ErrTooHeavy, weight, l.maxWeight)
+	}
+	return nil
+}
+
+// resize sets the capacity of the policy for a cache holding the supplied number of entries. A
+// cache limited only by weight can hold about as many entries as it holds when it is full, so the
+// policy is told that number when the cache is full and has changed by more than half since the
+// policy was last told.
+func (l *limits[K]) resize(size int, full bool) {
+	capacity := l.maxSize
+	if capacity <= 0 && l.maxWeight > 0 {
+		capacity = l.capacity
+		if full && (size > 2*capacity || 2*size < capacity) {
+			capacity = size
+		}
+	}
+	if capacity != l.capacity {
+		l.capacity = capacity
+		l.policy.SetCapacity(capacity)
+	}
+}
@@ -0,0 +1,108 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bsladewski/gollections/cache"
+)
+
+// length weighs entries by the length of their values.
+func length(_ int, value string) int64 {
+	return int64(len(value))
+}
+
+// TestCacheWeight tests that both kinds of cache evict entries by their total weight.
+func TestCacheWeight(t *testing.T) {
+	options := []cache.Option[int, string]{
+		cache.WithWeigher(length),
+		cache.WithMaxWeight[int, string](10),
+	}
+	for _, c := range []cache.CacheOf[int, string]{
+		cache.NewCacheOf(0, options...),
+		cache.NewConcurrentCacheOf(0, options...),
+	} {
+		c.Put(0, "aaaa")
+		c.Put(1, "bbbb")
+		if weight := c.Weight(); weight != 8 {
+			t.Fatalf("expected weight 8, got %d", weight)
+		}
+		// the least recently used entries are evicted until the cache is light enough
+		c.Get(0)
+		c.Put(2, "cccccc")
+		if _, err := c.Get(1); err == nil || c.Weight() != 10 || c.Size() != 2 {
+			t.Fatalf("expected 1 to be evicted, got weight %d and size %d", c.Weight(), c.Size())
+		}
+		// replacing an entry changes its weight
+		c.Put(2, "c")
+		if weight := c.Weight(); weight != 5 {
+			t.Fatalf("expected weight 5, got %d", weight)
+		}
+		if err := c.Put(3, "ddddddddddd"); !errors.Is(err, cache.ErrTooHeavy) {
+			t.Fatalf("expected too heavy error, got %v", err)
+		}
+		if _, err := c.Get(3); err == nil || c.Weight() != 5 || c.Size() != 2 {
+			t.Fatalf("expected the cache to be unchanged, got weight %d and size %d", c.Weight(), c.Size())
+		}
+		if err := c.Put(3, "dddddddddd"); err != nil || c.Weight() != 10 || c.Size() != 1 {
+			t.Fatalf("expected only 3 to be held, got weight %d and size %d, err: %v", c.Weight(), c.Size(), err)
+		}
+		c.SetMaxWeight(5)
+		if c.Weight() != 0 || c.Size() != 0 {
+			t.Fatalf("expected the cache to be emptied, got weight %d and size %d", c.Weight(), c.Size())
+		}
+		for i := 0; i < 5; i++ {
+			c.Put(i, "a")
+		}
+		c.Remove(0)
+		if weight := c.Weight(); weight != 4 {
+			t.Fatalf("expected weight 4, got %d", weight)
+		}
+		c.Clear()
+		if weight := c.Weight(); weight != 0 {
+			t.Fatalf("expected weight 0, got %d", weight)
+		}
+		// a cache is limited by both its size and its weight
+		c.SetMaxSize(2)
+		c.SetMaxWeight(0)
+		for i := 0; i < 5; i++ {
+			c.Put(i, "aaaa")
+		}
+		if c.Weight() != 8 || c.Size() != 2 {
+			t.Fatalf("expected 2 entries, got weight %d and size %d", c.Weight(), c.Size())
+		}
+	}
+}
+
+// TestCacheWeightPolicy tests that policies that rely on their capacity evict by weight.
+func TestCacheWeightPolicy(t *testing.T) {
+	for _, p := range policies {
+		t.Run(p.name, func(t *testing.T) {
+			c := cache.NewCacheOf(0,
+				cache.WithEvictionPolicy[int, string](p.newPolicy),
+				cache.WithWeigher(length),
+				cache.WithMaxWeight[int, string](100),
+			)
+			for i := 0; i < 1000; i++ {
+				c.Put(i%200, "aaaa")
+				if weight := c.Weight(); weight > 100 {
+					t.Fatalf("expected weight at most 100, got %d", weight)
+				}
+			}
+			if size := c.Size(); size == 0 {
+				t.Fatal("expected entries to be held")
+			}
+		})
+	}
+}
+
+// TestCacheWeightDefault tests that each entry weighs one without a weigher.
+func TestCacheWeightDefault(t *testing.T) {
+	c := cache.NewConcurrentCacheOf(0, cache.WithMaxWeight[int, int](3))
+	for i := 0; i < 5; i++ {
+		c.Put(i, i)
+	}
+	if c.Weight() != 3 || c.Size() != 3 {
+		t.Fatalf("expected 3 entries, got weight %d and size %d", c.Weight(), c.Size())
+	}
+}
@@ -0,0 +1,77 @@
+package cache
+
+import "sync"
+
+// A worker processes values in the background, one at a time in the order they were enqueued.
+type worker[T any] struct {
+	process func(T)
+	mutex   sync.Mutex
+	changed *sync.Cond
+	pending []T
+	busy    bool
+	closed  bool
+}
+
+// newWorker initializes a worker that processes values with the supplied function, and starts its
+// goroutine.
+func newWorker[T any](process func(T)) *worker[T] {
+	w := &worker[T]{process: process}
+	w.changed = sync.NewCond(&w.mutex)
+	go w.run()
+	return w
+}
+
+// enqueue adds a value to the pending values. Returns false if the worker is closed.
+func (w *worker[T]) enqueue(value T) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return false
+	}
+	w.pending = append(w.pending, value)
+	w.changed.Broadcast()
+	return true
+}
+
+// run processes pending values until the worker is closed and has no pending values.
+func (w *worker[T]) run() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for {
+		for len(w.pending) == 0 && !w.closed {
+			w.changed.Wait()
+		}
+		if len(w.pending) == 0 {
+			return
+		}
+		pending := w.pending
+		w.pending = nil
+		w.busy = true
+		w.mutex.Unlock()
+		for _, value := range pending {
+			w.process(value)
+		}
+		w.mutex.Lock()
+		w.busy = false
+		w.changed.Broadcast()
+	}
+}
+
+// wait waits until all pending values have been processed.
+func (w *worker[T]) wait() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for len(w.pending) > 0 || w.busy {
+		w.changed.Wait()
+	}
+}
+
+// close stops the goroutine of the worker once all pending values have been processed, and waits
+// for it to do so. Values enqueued after the worker is closed are not accepted.
+func (w *worker[T]) close() {
+	w.mutex.Lock()
+	w.closed = true
+	w.changed.Broadcast()
+	w.mutex.Unlock()
+	w.wait()
+}
@@ -0,0 +1,28 @@
+package gollections
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// sliceCopy copies the supplied values to the slice referenced by ptrToSlice.
+func sliceCopy[T any](ptrToSlice interface{}, values []T) error {
+	value := reflect.ValueOf(ptrToSlice)
+	if value.Kind() != reflect.Ptr {
+		return fmt.Errorf("supplied value of type %v is not a pointer", value.Type())
+	}
+	value = value.Elem()
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("supplied value of type %v is not a pointer to a slice", value.Type())
+	}
+	value.Set(reflect.MakeSlice(value.Type(), len(values), len(values)))
+	for index, v := range values {
+		listValue := reflect.ValueOf(v)
+		if value.Index(index).Kind() != listValue.Kind() {
+			return fmt.Errorf("cannot assign type %v to element of type %v", listValue.Kind(),
+				value.Index(index).Kind())
+		}
+		value.Index(index).Set(listValue)
+	}
+	return nil
+}
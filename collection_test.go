@@ -0,0 +1,560 @@
+package gollections_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// testCollection tests an implementation of Collection.
+func testCollection(t *testing.T, collection gollections.CollectionOf[any]) {
+	// clear, contains, is empty, remove, size, to array; empty collection
+	collection.Clear()
+	if collection.Contains(0) {
+		t.Fatal("expected contains to return false on empty collection")
+	}
+	if !collection.IsEmpty() {
+		t.Fatal("expected is empty to return true for an empty collection")
+	}
+	collection.Remove(0)
+	if size := collection.Size(); size != 0 {
+		t.Fatalf("expected size 0 on empty collection, got %d", size)
+	}
+	if array := collection.ToArray(); len(array) != 0 {
+		t.Fatalf("expected empty array, got %v", array)
+	}
+	// add
+	expected := []interface{}{5, 3, 8, 4, 2, 6, 9}
+	collection.Add(expected...)
+	if size := collection.Size(); size != 7 {
+		t.Errorf("expected size 7, got %d", size)
+	}
+	if got := collection.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// remove
+	collection.Remove(5, 9, 4, 0)
+	if size := collection.Size(); size != 4 {
+		t.Errorf("expected size 4, got %d", size)
+	}
+	expected = []interface{}{3, 8, 2, 6}
+	if got := collection.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// add; collection with tail removed
+	expected = []interface{}{3, 8, 2, 6, 7}
+	collection.Add(7)
+	if got := collection.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// clear
+	collection.Clear()
+	if size := collection.Size(); size != 0 {
+		t.Errorf("expected size 0, got %d", size)
+	}
+	if got := collection.ToArray(); !reflect.DeepEqual([]interface{}{}, got) {
+		t.Fatalf("expected empty collection, got %v", got)
+	}
+}
+
+// testCollectionSliceCopy tests the slice copy function of an implementation of Collection.
+func testCollectionSliceCopy(t *testing.T, list gollections.CollectionOf[any]) {
+	// slice copy; empty list
+	got := &[]int{}
+	if err := list.SliceCopy(got); err != nil {
+		t.Fatalf("expected empty array, got %v", *got)
+	}
+	// slice copy; list with ints
+	expected := []int{1, 2, 3}
+	list.Add(1, 2, 3)
+	if err := list.SliceCopy(got); err != nil || !reflect.DeepEqual(expected, *got) {
+		t.Fatalf("expected %v, got %v", expected, *got)
+	}
+	// slice copy; list with a string
+	list.Add("foo")
+	if err := list.SliceCopy(got); err == nil {
+		t.Fatalf("expected type error, got %v error: %v", *got, err)
+	}
+}
+
+// testList tests an implementation of List.
+func testList(t *testing.T, list gollections.ListOf[any]) {
+	// index of, insert, get, remove at, set; empty list
+	if index := list.IndexOf(0); index != -1 {
+		t.Fatalf("expected -1, got %d", index)
+	}
+	if err := list.Insert(3, 6); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	if _, err := list.Get(3); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	if err := list.RemoveAt(3); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	if err := list.Set(3, 6); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	// index of, insert, get, remove at, set
+	list.Add(6, 8, 3, 4, 5)
+	if index := list.IndexOf(6); index != 0 {
+		t.Fatalf("expected index 0, got %d", index)
+	}
+	if index := list.IndexOf(3); index != 2 {
+		t.Fatalf("expected index 2, got %d", index)
+	}
+	if index := list.IndexOf(5); index != 4 {
+		t.Fatalf("expected index 4, got %d", index)
+	}
+	expected := []interface{}{2, 7, 6, 8, 6, 3, 4, 9, 5}
+	list.Insert(0, 2, 7)
+	list.Insert(4, 6)
+	list.Insert(7, 9)
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if got, err := list.Get(3); err != nil || got != 8 {
+		t.Fatalf("expected 8, got %v, err: %v", got, err)
+	}
+	expected = []interface{}{7, 6, 8, 3, 4, 9}
+	list.RemoveAt(0)
+	list.RemoveAt(3)
+	list.RemoveAt(6)
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	expected = []interface{}{1, 6, 2, 3, 4, 7}
+	list.Set(0, 1)
+	list.Set(2, 2)
+	list.Set(5, 7)
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+// testQueue tests an implementation of Queue.
+func testQueue(t *testing.T, queue gollections.QueueOf[any]) {
+	// peek first, pop first; empty queue
+	if _, err := queue.PeekFirst(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if _, err := queue.PopFirst(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	// peek first, pop first
+	queue.Add(0, 1, 2, 3)
+	for i := 0; i < 4; i++ {
+		if got, err := queue.PeekFirst(); err != nil || i != got {
+			t.Fatalf("expected %d, got %v", i, got)
+		}
+		if got, err := queue.PopFirst(); err != nil || i != got {
+			t.Fatalf("expected %d, got %v", i, got)
+		}
+	}
+	if !queue.IsEmpty() {
+		t.Fatal("expected queue to be empty")
+	}
+}
+
+// testDeque tests an implementation of Deque.
+func testDeque(t *testing.T, deque gollections.DequeOf[any]) {
+	// peek last, pop last; empty deque
+	if _, err := deque.PeekLast(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if _, err := deque.PopLast(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	// add first
+	deque.AddFirst(0, 1, 2, 3)
+	expected := []interface{}{3, 2, 1, 0}
+	if got := deque.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// peek last, pop last
+	for i := 0; i < 4; i++ {
+		if got, err := deque.PeekLast(); err != nil || i != got {
+			t.Fatalf("expected %d, got %v", i, got)
+		}
+		if got, err := deque.PopLast(); err != nil || i != got {
+			t.Fatalf("expected %d, got %v", i, got)
+		}
+	}
+	if !deque.IsEmpty() {
+		t.Fatal("expected deque to be empty")
+	}
+}
+
+// testListTyped tests an implementation of List with a concrete element type.
+func testListTyped(t *testing.T, list gollections.ListOf[string]) {
+	list.Add("a", "b", "c")
+	if got, err := list.Get(1); err != nil || got != "b" {
+		t.Fatalf("expected b, got %q, err: %v", got, err)
+	}
+	if got, err := list.Get(3); err != gollections.ErrIndexOutOfBounds || got != "" {
+		t.Fatalf("expected zero value and index out of bounds error, got %q, err: %v", got, err)
+	}
+	expected := []string{"a", "b", "c"}
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	got := []string{}
+	if err := list.SliceCopy(&got); err != nil || !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v, err: %v", expected, got, err)
+	}
+}
+
+// testDequeEmptied tests that a deque emptied from either end holds no stale elements.
+func testDequeEmptied(t *testing.T, deque gollections.DequeOf[int]) {
+	deque.Add(1)
+	if _, err := deque.PopFirst(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, err := deque.PeekLast(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %d, err: %v", got, err)
+	}
+	deque.Add(2)
+	if _, err := deque.PopLast(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, err := deque.PeekFirst(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %d, err: %v", got, err)
+	}
+}
+
+// testStack tests an implementation of Stack.
+func testStack(t *testing.T, stack gollections.StackOf[any]) {
+	// peek last, pop last; empty stack
+	if _, err := stack.PeekLast(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if _, err := stack.PopLast(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	// peek last, pop last
+	stack.Add(3, 2, 1, 0)
+	for i := 0; i < 4; i++ {
+		if got, err := stack.PeekLast(); err != nil || i != got {
+			t.Fatalf("expected %d, got %v", i, got)
+		}
+		if got, err := stack.PopLast(); err != nil || i != got {
+			t.Fatalf("expected %d, got %v", i, got)
+		}
+	}
+	if !stack.IsEmpty() {
+		t.Fatal("expected stack to be empty")
+	}
+}
+
+// testListIterator tests the iterators of an implementation of List.
+func testListIterator(t *testing.T, list gollections.ListOf[int]) {
+	// all, backward; empty list
+	for value := range list.All() {
+		t.Fatalf("expected no values, got %d", value)
+	}
+	it := list.ListIterator()
+	if _, err := it.Next(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if err := it.Remove(); err != gollections.ErrIllegalState {
+		t.Fatalf("expected illegal state error, got %v", err)
+	}
+	// all, backward
+	list.Add(1, 2, 3, 4)
+	expected := []int{1, 2, 3, 4}
+	got := []int{}
+	for value := range list.All() {
+		got = append(got, value)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	expected = []int{4, 3, 2, 1}
+	got = []int{}
+	for value := range list.Backward() {
+		got = append(got, value)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// next, remove, set, add
+	it = list.ListIterator()
+	for it.HasNext() {
+		value, err := it.Next()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		switch value {
+		case 1:
+			if err := it.Remove(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		case 2:
+			it.Set(20)
+		case 3:
+			it.Add(5)
+		}
+	}
+	expected = []int{20, 3, 5, 4}
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// previous, remove, set, add
+	if index := it.PreviousIndex(); index != 3 {
+		t.Fatalf("expected previous index 3, got %d", index)
+	}
+	if got, err := it.Previous(); err != nil || got != 4 {
+		t.Fatalf("expected 4, got %d, err: %v", got, err)
+	}
+	if err := it.Remove(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, err := it.Previous(); err != nil || got != 5 {
+		t.Fatalf("expected 5, got %d, err: %v", got, err)
+	}
+	it.Set(50)
+	it.Add(4)
+	if index := it.NextIndex(); index != 3 {
+		t.Fatalf("expected next index 3, got %d", index)
+	}
+	if err := it.Set(40); err != gollections.ErrIllegalState {
+		t.Fatalf("expected illegal state error, got %v", err)
+	}
+	expected = []int{20, 3, 4, 50}
+	if got := list.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// concurrent modification
+	it = list.ListIterator()
+	list.Add(6)
+	if _, err := it.Next(); err != gollections.ErrConcurrentModification {
+		t.Fatalf("expected concurrent modification error, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r != gollections.ErrConcurrentModification {
+			t.Fatalf("expected concurrent modification panic, got %v", r)
+		}
+	}()
+	for value := range list.All() {
+		list.Remove(value)
+	}
+}
+
+// expectList fails the test if the list does not hold exactly the expected values.
+func expectList(t *testing.T, list gollections.ListOf[int], expected ...int) {
+	t.Helper()
+	got := list.ToArray()
+	if len(got) == 0 && len(expected) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+// testListBulk tests the bulk operations of an implementation of List. The list must be empty.
+func testListBulk(t *testing.T, list gollections.ListOf[int]) {
+	other := gollections.NewArrayListOf[int]()
+	other.Add(1, 2, 3)
+	// add all, insert all
+	list.AddAll(other)
+	list.AddAll(list)
+	expectList(t, list, 1, 2, 3, 1, 2, 3)
+	if err := list.InsertAll(1, other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectList(t, list, 1, 1, 2, 3, 2, 3, 1, 2, 3)
+	if err := list.InsertAll(list.Size(), other); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	// remove range
+	if err := list.RemoveRange(1, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectList(t, list, 1, 2, 3, 1, 2, 3)
+	for _, r := range [][2]int{{-1, 2}, {3, 2}, {0, 7}} {
+		if err := list.RemoveRange(r[0], r[1]); err != gollections.ErrIndexOutOfBounds {
+			t.Fatalf("expected index out of bounds error for %v, got %v", r, err)
+		}
+	}
+	if err := list.RemoveRange(6, 6); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// replace all, remove if, retain all
+	list.ReplaceAll(func(value int) int { return value * 10 })
+	expectList(t, list, 10, 20, 30, 10, 20, 30)
+	list.RemoveIf(func(value int) bool { return value == 20 })
+	expectList(t, list, 10, 30, 10, 30)
+	keep := gollections.NewHashSetOf[int]()
+	keep.Add(30, 40)
+	list.RetainAll(keep)
+	expectList(t, list, 30, 30)
+	list.Add(10)
+	list.RetainAll(other)
+	expectList(t, list)
+	// bulk operations invalidate iterators
+	list.Add(1, 2)
+	it := list.Iterator()
+	list.RemoveIf(func(value int) bool { return value == 1 })
+	if _, err := it.Next(); err != gollections.ErrConcurrentModification {
+		t.Fatalf("expected concurrent modification error, got %v", err)
+	}
+}
+
+// testSubList tests the sub list views of an implementation of List. The list must be empty.
+func testSubList(t *testing.T, list gollections.ListOf[int]) {
+	list.Add(0, 1, 2, 3, 4, 5, 6, 7)
+	if _, err := list.SubList(3, 2); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	view, err := list.SubList(2, 6)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectList(t, view, 2, 3, 4, 5)
+	if value, err := view.Get(0); err != nil || value != 2 {
+		t.Fatalf("expected 2, got %d, err: %v", value, err)
+	}
+	if _, err := view.Get(4); err != gollections.ErrIndexOutOfBounds {
+		t.Fatalf("expected index out of bounds error, got %v", err)
+	}
+	if view.IndexOf(4) != 2 || view.IndexOf(0) != -1 || !view.Contains(2, 5) || view.Contains(6) {
+		t.Fatal("expected view to contain exactly 2 to 5")
+	}
+	// writes go through to the list
+	view.Set(0, 20)
+	view.ReplaceAll(func(value int) int { return value + 1 })
+	expectList(t, list, 0, 1, 21, 4, 5, 6, 6, 7)
+	view.Add(8)
+	view.Insert(0, 9)
+	expectList(t, view, 9, 21, 4, 5, 6, 8)
+	expectList(t, list, 0, 1, 9, 21, 4, 5, 6, 8, 6, 7)
+	view.RemoveAt(1)
+	view.Remove(5)
+	view.RemoveIf(func(value int) bool { return value == 6 })
+	expectList(t, view, 9, 4, 8)
+	expectList(t, list, 0, 1, 9, 4, 8, 6, 7)
+	// nested views
+	nested, err := view.SubList(1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nested.Add(10)
+	expectList(t, nested, 4, 8, 10)
+	expectList(t, view, 9, 4, 8, 10)
+	nested.Clear()
+	expectList(t, view, 9)
+	expectList(t, list, 0, 1, 9, 6, 7)
+	// iteration
+	view.AddAll(view)
+	got := []int{}
+	for value := range view.Backward() {
+		got = append(got, value)
+	}
+	if !reflect.DeepEqual([]int{9, 9}, got) {
+		t.Fatalf("expected [9 9], got %v", got)
+	}
+	it := view.ListIterator()
+	it.Next()
+	it.Remove()
+	it.Add(11)
+	expectList(t, list, 0, 1, 11, 9, 6, 7)
+	// a view of an entire list can append to it
+	all, _ := list.SubList(0, list.Size())
+	all.Add(12)
+	expectList(t, list, 0, 1, 11, 9, 6, 7, 12)
+	// structural changes outside of the view invalidate it
+	list.Add(13)
+	if _, err := view.Get(0); err != gollections.ErrConcurrentModification {
+		t.Fatalf("expected concurrent modification error, got %v", err)
+	}
+	if err := view.Insert(0, 1); err != gollections.ErrConcurrentModification {
+		t.Fatalf("expected concurrent modification error, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r != gollections.ErrConcurrentModification {
+			t.Fatalf("expected concurrent modification panic, got %v", r)
+		}
+	}()
+	view.Size()
+}
+
+// testSet tests an implementation of Set. The factory must return an empty set of the same kind.
+func testSet(t *testing.T, newSet func(...gollections.Option[int]) gollections.SetOf[int]) {
+	set := newSet()
+	// add; duplicate values
+	set.Add(1, 2, 2, 3, 1)
+	if size := set.Size(); size != 3 {
+		t.Fatalf("expected size 3, got %d", size)
+	}
+	if !set.Contains(1, 2, 3) || set.Contains(4) {
+		t.Fatal("expected set to contain exactly 1, 2 and 3")
+	}
+	// remove
+	set.Remove(2, 4)
+	if set.Contains(2) || set.Size() != 2 {
+		t.Fatalf("expected 2 to be removed, got %v", set.ToArray())
+	}
+	set.Add(2)
+	// set algebra
+	other := newSet()
+	other.Add(3, 4, 5)
+	sorted := func(s gollections.SetOf[int]) []int {
+		values := s.ToArray()
+		sort.Ints(values)
+		return values
+	}
+	for _, test := range []struct {
+		name     string
+		got      gollections.SetOf[int]
+		expected []int
+	}{
+		{"union", set.Union(other), []int{1, 2, 3, 4, 5}},
+		{"intersection", set.Intersection(other), []int{3}},
+		{"difference", set.Difference(other), []int{1, 2}},
+		{"symmetric difference", set.SymmetricDifference(other), []int{1, 2, 4, 5}},
+	} {
+		if got := sorted(test.got); !reflect.DeepEqual(test.expected, got) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+	if expected, got := []int{1, 2, 3}, sorted(set); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected operands to be unchanged, got %v", got)
+	}
+	// subset, superset
+	subset := newSet()
+	subset.Add(1, 3)
+	if !subset.IsSubsetOf(set) || subset.IsSubsetOf(other) || !set.IsSubsetOf(set) {
+		t.Fatal("unexpected subset result")
+	}
+	if !set.IsSupersetOf(subset) || other.IsSupersetOf(subset) || !set.IsSupersetOf(newSet()) {
+		t.Fatal("unexpected superset result")
+	}
+	// iterator remove
+	it := set.Iterator()
+	for it.HasNext() {
+		value, err := it.Next()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if value%2 == 1 {
+			if err := it.Remove(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+	}
+	if expected, got := []int{2}, sorted(set); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	set.Add(1)
+	if !set.Contains(1) {
+		t.Fatal("expected removed value to be added again")
+	}
+	// clear
+	set.Clear()
+	if !set.IsEmpty() {
+		t.Fatalf("expected empty set, got %v", set.ToArray())
+	}
+}
@@ -0,0 +1,6 @@
+package gollections
+
+// A Comparator orders two values. It returns a negative number if a is less than b, a positive
+// number if a is greater than b and zero if they are equal.
+// For ordered types, cmp.Compare may be used as a Comparator.
+type Comparator[T any] func(a, b T) int
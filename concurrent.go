@@ -0,0 +1,444 @@
+package gollections
+
+import (
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
+// concurrentNode is a node of a lock-free queue or stack. The value is never changed after the
+// node is linked. The node is marked as removed by the goroutine that removes its element, so
+// that every element is removed exactly once even when it is removed from the middle of the
+// collection.
+type concurrentNode[T any] struct {
+	value   T
+	removed atomic.Bool
+	next    atomic.Pointer[concurrentNode[T]]
+	// sequence numbers the nodes of a queue in the order they are linked
+	sequence uint64
+}
+
+// claim marks the node as removed. Returns false if it has already been removed.
+func (n *concurrentNode[T]) claim() bool {
+	return n.removed.CompareAndSwap(false, true)
+}
+
+// sweep calls the visit function on each node that has not been removed of the chain of nodes
+// after the supplied node. The visit function returns true if the node has been removed since.
+// Removed nodes are unlinked from the chain on the way, except for the last node of the chain,
+// after which other goroutines may be linking a node. A removed node that is unlinked
+// concurrently with its predecessor may be linked again, but it is unlinked by the next sweep.
+func sweep[T any](first *concurrentNode[T], visit func(*concurrentNode[T]) bool) {
+	previous := first
+	for n := previous.next.Load(); n != nil; n = previous.next.Load() {
+		if !n.removed.Load() && !visit(n) {
+			previous = n
+			continue
+		}
+		next := n.next.Load()
+		if next == nil {
+			return
+		}
+		previous.next.CompareAndSwap(n, next)
+	}
+}
+
+// collect gets a visit function for sweep that adds each node to the supplied slice.
+func collect[T any](nodes *[]*concurrentNode[T]) func(*concurrentNode[T]) bool {
+	return func(n *concurrentNode[T]) bool {
+		*nodes = append(*nodes, n)
+		return false
+	}
+}
+
+// removal gets a visit function for sweep that removes each node whose value matches the
+// predicate.
+func removal[T any](size *atomic.Int64, predicate func(T) bool) func(*concurrentNode[T]) bool {
+	return func(n *concurrentNode[T]) bool {
+		if !predicate(n.value) {
+			return false
+		}
+		if n.claim() {
+			size.Add(-1)
+		}
+		return true
+	}
+}
+
+// nodeValues gets the values of the supplied nodes.
+func nodeValues[T any](nodes []*concurrentNode[T]) []T {
+	array := make([]T, len(nodes))
+	for i, n := range nodes {
+		array[i] = n.value
+	}
+	return array
+}
+
+// concurrentIterator is an iterator over a snapshot of the elements of a lock-free collection.
+// Removing an element through the iterator removes it from the collection if no other goroutine
+// has removed it first.
+type concurrentIterator[T any] struct {
+	nodes  []*concurrentNode[T]
+	size   *atomic.Int64
+	cursor int
+	last   int
+}
+
+func (i *concurrentIterator[T]) HasNext() bool {
+	return i.cursor < len(i.nodes)
+}
+
+func (i *concurrentIterator[T]) Next() (T, error) {
+	if !i.HasNext() {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	i.last = i.cursor
+	i.cursor++
+	return i.nodes[i.last].value, nil
+}
+
+func (i *concurrentIterator[T]) Remove() error {
+	if i.last < 0 {
+		return ErrIllegalState
+	}
+	if i.nodes[i.last].claim() {
+		i.size.Add(-1)
+	}
+	i.last = -1
+	return nil
+}
+
+// approximate converts the counter of a lock-free collection to a size. The counter is updated
+// after each change is made, so it can briefly be negative while elements are removed.
+func approximate(size *atomic.Int64) int {
+	return int(max(size.Load(), 0))
+}
+
+// ConcurrentQueue is a lock-free implementation of a queue that may be shared between goroutines,
+// based on the algorithm of Michael and Scott. Adding and removing elements never blocks.
+// Iteration is weakly consistent: it visits a snapshot of the elements taken when iteration
+// starts and never fails due to concurrent modification. Elements removed from the middle of the
+// queue are unlinked by the next method that traverses the queue. A queue must be initialized
+// with NewConcurrentQueueOf.
+type ConcurrentQueue[T any] struct {
+	head     atomic.Pointer[concurrentNode[T]]
+	tail     atomic.Pointer[concurrentNode[T]]
+	size     atomic.Int64
+	equality Equaler[T]
+}
+
+// equalityStrategy gets the equality strategy of the queue.
+func (q *ConcurrentQueue[T]) equalityStrategy() Equaler[T] {
+	return q.equality
+}
+
+// emptyCollection initializes an empty queue with the same configuration.
+func (q *ConcurrentQueue[T]) emptyCollection(bool) CollectionOf[T] {
+	return newConcurrentQueue(q.equality)
+}
+
+// nodes gets the nodes of the queue from first to last.
+func (q *ConcurrentQueue[T]) nodes() []*concurrentNode[T] {
+	var nodes []*concurrentNode[T]
+	sweep(q.head.Load(), collect(&nodes))
+	return nodes
+}
+
+// Add appends new elements to the end of the queue.
+func (q *ConcurrentQueue[T]) Add(values ...T) {
+	for _, value := range values {
+		n := &concurrentNode[T]{value: value}
+		for {
+			tail := q.tail.Load()
+			next := tail.next.Load()
+			if tail != q.tail.Load() {
+				continue
+			}
+			if next != nil {
+				// another goroutine has linked a node but not yet moved the tail
+				q.tail.CompareAndSwap(tail, next)
+				continue
+			}
+			n.sequence = tail.sequence + 1
+			if tail.next.CompareAndSwap(nil, n) {
+				q.tail.CompareAndSwap(tail, n)
+				break
+			}
+		}
+		q.size.Add(1)
+	}
+}
+
+// All gets a sequence over a snapshot of the elements of the queue.
+func (q *ConcurrentQueue[T]) All() iter.Seq[T] {
+	return slices.Values(q.ToArray())
+}
+
+// Clear removes all elements from the queue. The elements are unlinked at once by making the last
+// element the sentinel of the queue; elements added concurrently are kept.
+func (q *ConcurrentQueue[T]) Clear() {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		if next := tail.next.Load(); next != nil {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if head == tail {
+			return
+		}
+		if !q.head.CompareAndSwap(head, tail) {
+			continue
+		}
+		// claim the elements of the unlinked nodes, including the new sentinel; the sequence
+		// bounds the walk as the tail may be unlinked concurrently if it was removed already
+		for n := head.next.Load(); n != nil && n.sequence <= tail.sequence; n = n.next.Load() {
+			if n.claim() {
+				q.size.Add(-1)
+			}
+		}
+		return
+	}
+}
+
+// Contains checks if the queue contains all specified values.
+func (q *ConcurrentQueue[T]) Contains(values ...T) bool {
+	return containsAll(q.equality, q.ToArray(), values)
+}
+
+// IsEmpty checks if the queue contains no elements.
+func (q *ConcurrentQueue[T]) IsEmpty() bool {
+	_, err := q.PeekFirst()
+	return err != nil
+}
+
+// Iterator gets an iterator over a snapshot of the elements of the queue.
+func (q *ConcurrentQueue[T]) Iterator() Iterator[T] {
+	return &concurrentIterator[T]{nodes: q.nodes(), size: &q.size, last: -1}
+}
+
+// Remove removes all specified values from the queue.
+func (q *ConcurrentQueue[T]) Remove(values ...T) {
+	sweep(q.head.Load(), removal(&q.size, matcher(q.equality, values)))
+}
+
+// Size gets the number of elements in the queue. The size is approximate while other goroutines
+// modify the queue.
+func (q *ConcurrentQueue[T]) Size() int {
+	return approximate(&q.size)
+}
+
+// SliceCopy copies all values in the queue to the supplied slice.
+func (q *ConcurrentQueue[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, q.ToArray())
+}
+
+// ToArray gets an array representation of the queue.
+func (q *ConcurrentQueue[T]) ToArray() []T {
+	return nodeValues(q.nodes())
+}
+
+// PeekFirst gets the value of the first element in the queue.
+func (q *ConcurrentQueue[T]) PeekFirst() (T, error) {
+	for n := q.head.Load().next.Load(); n != nil; n = n.next.Load() {
+		if !n.removed.Load() {
+			return n.value, nil
+		}
+	}
+	var zero T
+	return zero, ErrNoSuchElement
+}
+
+// PopFirst gets the value of the first element in the queue. The element is removed.
+func (q *ConcurrentQueue[T]) PopFirst() (T, error) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, ErrNoSuchElement
+		}
+		if head == tail {
+			// the tail is behind a node that another goroutine has linked
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if !q.head.CompareAndSwap(head, next) {
+			continue
+		}
+		// the next node is now the sentinel; claim its element unless it was removed already
+		if next.claim() {
+			q.size.Add(-1)
+			return next.value, nil
+		}
+	}
+}
+
+// newConcurrentQueue initializes an empty queue that uses the supplied equality strategy.
+func newConcurrentQueue[T any](equality Equaler[T]) *ConcurrentQueue[T] {
+	q := &ConcurrentQueue[T]{equality: equality}
+	sentinel := &concurrentNode[T]{}
+	sentinel.removed.Store(true)
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// NewConcurrentQueueOf initializes a lock-free queue that may be shared between goroutines.
+func NewConcurrentQueueOf[T any](options ...Option[T]) QueueOf[T] {
+	return newConcurrentQueue(newOptions(options).equality)
+}
+
+// ConcurrentStack is a lock-free implementation of a stack that may be shared between goroutines,
+// based on the algorithm of Treiber. Adding and removing elements never blocks. Iteration is
+// weakly consistent: it visits a snapshot of the elements, from first added to last added, taken
+// when iteration starts and never fails due to concurrent modification. Elements removed from the
+// middle of the stack are unlinked by the next method that traverses the stack. The zero value is
+// an empty stack.
+type ConcurrentStack[T any] struct {
+	top      atomic.Pointer[concurrentNode[T]]
+	size     atomic.Int64
+	equality Equaler[T]
+}
+
+// equalityStrategy gets the equality strategy of the stack.
+func (s *ConcurrentStack[T]) equalityStrategy() Equaler[T] {
+	return s.equality
+}
+
+// emptyCollection initializes an empty stack with the same configuration.
+func (s *ConcurrentStack[T]) emptyCollection(bool) CollectionOf[T] {
+	return &ConcurrentStack[T]{equality: s.equality}
+}
+
+// sweep calls the visit function on each node of the stack from top to bottom as for the sweep
+// function, also unlinking the top node if it has been removed.
+func (s *ConcurrentStack[T]) sweep(visit func(*concurrentNode[T]) bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			return
+		}
+		if !top.removed.Load() && !visit(top) {
+			sweep(top, visit)
+			return
+		}
+		s.top.CompareAndSwap(top, top.next.Load())
+	}
+}
+
+// nodes gets the nodes of the stack from first added to last added.
+func (s *ConcurrentStack[T]) nodes() []*concurrentNode[T] {
+	var nodes []*concurrentNode[T]
+	s.sweep(collect(&nodes))
+	slices.Reverse(nodes)
+	return nodes
+}
+
+// Add pushes new elements onto the top of the stack.
+func (s *ConcurrentStack[T]) Add(values ...T) {
+	for _, value := range values {
+		n := &concurrentNode[T]{value: value}
+		for {
+			top := s.top.Load()
+			n.next.Store(top)
+			if s.top.CompareAndSwap(top, n) {
+				break
+			}
+		}
+		s.size.Add(1)
+	}
+}
+
+// All gets a sequence over a snapshot of the elements of the stack.
+func (s *ConcurrentStack[T]) All() iter.Seq[T] {
+	return slices.Values(s.ToArray())
+}
+
+// Clear removes all elements from the stack. The elements are unlinked at once; elements added
+// concurrently are kept.
+func (s *ConcurrentStack[T]) Clear() {
+	// claim the elements of the unlinked nodes, no other goroutine can link a node after them
+	for n := s.top.Swap(nil); n != nil; n = n.next.Load() {
+		if n.claim() {
+			s.size.Add(-1)
+		}
+	}
+}
+
+// Contains checks if the stack contains all specified values.
+func (s *ConcurrentStack[T]) Contains(values ...T) bool {
+	return containsAll(s.equality, s.ToArray(), values)
+}
+
+// IsEmpty checks if the stack contains no elements.
+func (s *ConcurrentStack[T]) IsEmpty() bool {
+	_, err := s.PeekLast()
+	return err != nil
+}
+
+// Iterator gets an iterator over a snapshot of the elements of the stack.
+func (s *ConcurrentStack[T]) Iterator() Iterator[T] {
+	return &concurrentIterator[T]{nodes: s.nodes(), size: &s.size, last: -1}
+}
+
+// Remove removes all specified values from the stack.
+func (s *ConcurrentStack[T]) Remove(values ...T) {
+	s.sweep(removal(&s.size, matcher(s.equality, values)))
+}
+
+// Size gets the number of elements in the stack. The size is approximate while other goroutines
+// modify the stack.
+func (s *ConcurrentStack[T]) Size() int {
+	return approximate(&s.size)
+}
+
+// SliceCopy copies all values in the stack to the supplied slice.
+func (s *ConcurrentStack[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, s.ToArray())
+}
+
+// ToArray gets an array representation of the stack.
+func (s *ConcurrentStack[T]) ToArray() []T {
+	return nodeValues(s.nodes())
+}
+
+// PeekLast gets the value of the element on the top of the stack.
+func (s *ConcurrentStack[T]) PeekLast() (T, error) {
+	for n := s.top.Load(); n != nil; n = n.next.Load() {
+		if !n.removed.Load() {
+			return n.value, nil
+		}
+	}
+	var zero T
+	return zero, ErrNoSuchElement
+}
+
+// PopLast gets the value of the element on the top of the stack. The element is removed.
+func (s *ConcurrentStack[T]) PopLast() (T, error) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var zero T
+			return zero, ErrNoSuchElement
+		}
+		if !s.top.CompareAndSwap(top, top.next.Load()) {
+			continue
+		}
+		// claim the element of the unlinked node unless it was removed already
+		if top.claim() {
+			s.size.Add(-1)
+			return top.value, nil
+		}
+	}
+}
+
+// NewConcurrentStackOf initializes a lock-free stack that may be shared between goroutines.
+func NewConcurrentStackOf[T any](options ...Option[T]) StackOf[T] {
+	return &ConcurrentStack[T]{equality: newOptions(options).equality}
+}
@@ -0,0 +1,258 @@
+package gollections_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"weak"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the lock-free queue and stack as implementations of Queue and Stack.
+func TestConcurrentInterfaces(t *testing.T) {
+	testCollection(t, gollections.NewConcurrentQueueOf[any]())
+	testQueue(t, gollections.NewConcurrentQueueOf[any]())
+	testCollection(t, gollections.NewConcurrentStackOf[any]())
+	testStack(t, gollections.NewConcurrentStackOf[any]())
+}
+
+// TestConcurrentRemove tests removing elements from the middle of the lock-free collections.
+func TestConcurrentRemove(t *testing.T) {
+	queue := gollections.NewConcurrentQueueOf[int]()
+	queue.Add(1, 2, 3, 4)
+	queue.Remove(1, 3)
+	if queue.Size() != 2 || queue.Contains(1) || queue.Contains(3) {
+		t.Fatalf("expected [2 4], got %v", queue.ToArray())
+	}
+	for _, expected := range []int{2, 4} {
+		if value, err := queue.PopFirst(); err != nil || value != expected {
+			t.Fatalf("expected %d, got %d, err: %v", expected, value, err)
+		}
+	}
+	if _, err := queue.PopFirst(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+
+	stack := gollections.NewConcurrentStackOf[int]()
+	stack.Add(1, 2, 3, 4)
+	it := stack.Iterator()
+	for it.HasNext() {
+		if value, _ := it.Next(); value%2 == 0 {
+			if err := it.Remove(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := it.Remove(); err != gollections.ErrIllegalState {
+		t.Fatalf("expected illegal state error, got %v", err)
+	}
+	if value, err := stack.PopLast(); err != nil || value != 3 {
+		t.Fatalf("expected 3, got %d, err: %v", value, err)
+	}
+	if stack.Size() != 1 || !stack.Contains(1) {
+		t.Fatalf("expected [1], got %v", stack.ToArray())
+	}
+}
+
+// testConcurrentExchange adds and removes elements on several goroutines and checks that every
+// element is removed exactly once.
+func testConcurrentExchange(t *testing.T, add func(int), remove func() (int, error)) {
+	const goroutines, count = 8, 2000
+	var wg sync.WaitGroup
+	seen := make([]int, goroutines*count)
+	var mutex sync.Mutex
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			removed := make([]int, 0, count)
+			for i := 0; i < count; i++ {
+				add(g*count + i)
+				for {
+					value, err := remove()
+					if err == nil {
+						removed = append(removed, value)
+						break
+					}
+				}
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, value := range removed {
+				seen[value]++
+			}
+		}(g)
+	}
+	wg.Wait()
+	for value, n := range seen {
+		if n != 1 {
+			t.Fatalf("expected value %d to be removed once, removed %d times", value, n)
+		}
+	}
+}
+
+// TestConcurrentQueue tests the lock-free queue under concurrent use.
+func TestConcurrentQueue(t *testing.T) {
+	queue := gollections.NewConcurrentQueueOf[int]()
+	testConcurrentExchange(t, func(value int) { queue.Add(value) }, queue.PopFirst)
+	if !queue.IsEmpty() || queue.Size() != 0 {
+		t.Fatalf("expected empty queue, got %v", queue.ToArray())
+	}
+}
+
+// TestConcurrentStack tests the lock-free stack under concurrent use.
+func TestConcurrentStack(t *testing.T) {
+	stack := gollections.NewConcurrentStackOf[int]()
+	testConcurrentExchange(t, func(value int) { stack.Add(value) }, stack.PopLast)
+	if !stack.IsEmpty() || stack.Size() != 0 {
+		t.Fatalf("expected empty stack, got %v", stack.ToArray())
+	}
+}
+
+// TestConcurrentQueueOrder tests that a single consumer sees the elements of each producer in the
+// order they were added.
+func TestConcurrentQueueOrder(t *testing.T) {
+	queue := gollections.NewConcurrentQueueOf[[2]int]()
+	const producers, count = 4, 2000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				queue.Add([2]int{p, i})
+			}
+		}(p)
+	}
+	next := make([]int, producers)
+	for received := 0; received < producers*count; {
+		value, err := queue.PopFirst()
+		if err != nil {
+			continue
+		}
+		if value[1] != next[value[0]] {
+			t.Fatalf("expected element %d of producer %d, got %d", next[value[0]], value[0], value[1])
+		}
+		next[value[0]]++
+		received++
+	}
+	wg.Wait()
+}
+
+// BenchmarkConcurrentQueue compares the lock-free queue to a linked queue guarded by a mutex.
+func BenchmarkConcurrentQueue(b *testing.B) {
+	queues := []struct {
+		name  string
+		queue gollections.QueueOf[int]
+	}{
+		{"lock-free", gollections.NewConcurrentQueueOf[int]()},
+		{"synchronized", gollections.SynchronizedQueue(gollections.NewLinkedQueueOf[int]())},
+	}
+	for _, q := range queues {
+		b.Run(q.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					q.queue.Add(i)
+					q.queue.PopFirst()
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkConcurrentStack compares the lock-free stack to a linked stack guarded by a mutex.
+func BenchmarkConcurrentStack(b *testing.B) {
+	stacks := []struct {
+		name  string
+		stack gollections.StackOf[int]
+	}{
+		{"lock-free", gollections.NewConcurrentStackOf[int]()},
+		{"synchronized", gollections.SynchronizedStack(gollections.NewLinkedStackOf[int]())},
+	}
+	for _, s := range stacks {
+		b.Run(s.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					s.stack.Add(i)
+					s.stack.PopLast()
+				}
+			})
+		})
+	}
+}
+
+// testConcurrentUnlink tests that elements removed from a lock-free collection are not retained by
+// it. The last element added to a queue is retained as its sentinel by Clear.
+func testConcurrentUnlink(t *testing.T, c gollections.CollectionOf[*[64]byte], retained int) {
+	pointers := make([]weak.Pointer[[64]byte], 10)
+	for i := range pointers {
+		value := &[64]byte{}
+		pointers[i] = weak.Make(value)
+		c.Add(value)
+	}
+	// remove every element except the first and last from the middle
+	c.Remove(pointers[1].Value(), pointers[2].Value(), pointers[5].Value(), pointers[8].Value())
+	runtime.GC()
+	for _, i := range []int{1, 2, 5, 8} {
+		if pointers[i].Value() != nil {
+			t.Fatalf("%T: expected removed element %d to be unlinked", c, i)
+		}
+	}
+	c.Clear()
+	runtime.GC()
+	for i, pointer := range pointers[:len(pointers)-retained] {
+		if pointer.Value() != nil {
+			t.Fatalf("%T: expected cleared element %d to be unlinked", c, i)
+		}
+	}
+	if c.Size() != 0 || !c.IsEmpty() {
+		t.Fatalf("%T: expected empty collection, got %d elements", c, c.Size())
+	}
+}
+
+// TestConcurrentUnlink tests that the lock-free collections unlink removed elements.
+func TestConcurrentUnlink(t *testing.T) {
+	testConcurrentUnlink(t, gollections.NewConcurrentQueueOf[*[64]byte](), 1)
+	testConcurrentUnlink(t, gollections.NewConcurrentStackOf[*[64]byte](), 0)
+}
+
+// TestConcurrentClear tests that the size of the lock-free collections stays consistent while
+// elements are added, removed and cleared concurrently.
+func TestConcurrentClear(t *testing.T) {
+	for _, c := range []gollections.CollectionOf[int]{
+		gollections.NewConcurrentQueueOf[int](),
+		gollections.NewConcurrentStackOf[int](),
+	} {
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					c.Add(i, i+1, i+2)
+					switch {
+					case g == 0 && i%50 == 0:
+						c.Clear()
+					case g == 1:
+						c.Remove(i)
+					case g == 2:
+						for it := c.Iterator(); it.HasNext(); {
+							if value, _ := it.Next(); value%7 == 0 {
+								it.Remove()
+							}
+						}
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		if size, values := c.Size(), c.ToArray(); size != len(values) {
+			t.Fatalf("%T: expected size %d, got %d", c, len(values), size)
+		}
+		c.Clear()
+		if c.Size() != 0 || len(c.ToArray()) != 0 {
+			t.Fatalf("%T: expected empty collection, got %v", c, c.ToArray())
+		}
+	}
+}
@@ -1,2 +1,13 @@
 // Package gollections provides types and functions for storing and manipulating groups of objects.
+//
+// The generic interfaces of collections, caches and tries, and the constructors of collections,
+// streams, caches and tries, are named with the suffix Of, such as ListOf, CacheOf, NewArrayListOf
+// and NewStreamOfValues. The suffix sets them apart from the untyped Collection, List, Queue, Deque
+// and Stack and the constructors kept from earlier versions. The types that implement the
+// interfaces, such as LinkedList and ArrayList, are named without the suffix.
+//
+// Constructors of collections return the interface of the kind of collection they create, such as
+// ListOf or DequeOf. Types with operations that no interface describes, such as the capacity of an
+// ArrayList or ArrayDeque created with a capacity, the handles of a PriorityQueue or the Do method
+// of synchronized wrappers, are returned as pointers.
 package gollections
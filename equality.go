@@ -0,0 +1,323 @@
+package gollections
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// An Equaler decides whether two values are equal.
+type Equaler[T any] interface {
+	// Equal checks if the supplied values are equal.
+	Equal(a, b T) bool
+}
+
+// A Hasher is an Equaler that can also hash values, which lets collections find equal values
+// without comparing every pair. Values that are equal must have the same hash.
+type Hasher[T any] interface {
+	Equaler[T]
+	// Hash gets the hash of the supplied value.
+	Hash(value T) uint64
+}
+
+// seed is used for all hashes computed by the built in equality strategies.
+var seed = maphash.MakeSeed()
+
+// maxHashDepth limits how deeply nested values are hashed by deep equality, which also prevents
+// hashing of cyclic values from recursing forever.
+const maxHashDepth = 8
+
+// isComparable checks if the supplied value can be compared using ==.
+func isComparable(value reflect.Value) bool {
+	return !value.IsValid() || value.Comparable()
+}
+
+// directlyComparable checks if every value of the supplied type can be compared using == without
+// panicking. Interfaces are excluded, as comparing them panics if they hold values that cannot be
+// compared.
+func directlyComparable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Array:
+		return directlyComparable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !directlyComparable(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface, reflect.Slice, reflect.Map, reflect.Func:
+		return false
+	}
+	return true
+}
+
+// identityEquality compares values using ==.
+type identityEquality[T any] struct{}
+
+// Equal compares values of directly comparable types using == without reflection or allocation,
+// and inspects the values only if the type may hold values that cannot be compared.
+func (identityEquality[T]) Equal(a, b T) bool {
+	if directlyComparable(reflect.TypeFor[T]()) {
+		return interface{}(a) == interface{}(b)
+	}
+	return identical(interface{}(a), interface{}(b))
+}
+
+// identical compares values using == if both can be compared, or reflect.DeepEqual otherwise.
+func identical(x, y interface{}) bool {
+	if isComparable(reflect.ValueOf(x)) && isComparable(reflect.ValueOf(y)) {
+		return x == y
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func (identityEquality[T]) Hash(value T) uint64 {
+	v := interface{}(value)
+	if directlyComparable(reflect.TypeFor[T]()) {
+		return maphash.Comparable(seed, v)
+	}
+	if rv := reflect.ValueOf(v); !isComparable(rv) {
+		return deepHash(rv)
+	}
+	return maphash.Comparable(seed, v)
+}
+
+// deepEquality compares values using reflect.DeepEqual.
+type deepEquality[T any] struct{}
+
+func (deepEquality[T]) Equal(a, b T) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func (deepEquality[T]) Hash(value T) uint64 {
+	return deepHash(reflect.ValueOf(interface{}(value)))
+}
+
+// keyEquality compares values by a key derived from each value.
+type keyEquality[T any, K comparable] struct {
+	key func(T) K
+}
+
+func (e keyEquality[T, K]) Equal(a, b T) bool {
+	return e.key(a) == e.key(b)
+}
+
+func (e keyEquality[T, K]) Hash(value T) uint64 {
+	return maphash.Comparable(seed, e.key(value))
+}
+
+// equalFunc compares values using a function.
+type equalFunc[T any] func(a, b T) bool
+
+func (f equalFunc[T]) Equal(a, b T) bool {
+	return f(a, b)
+}
+
+// deepHash hashes a value consistently with reflect.DeepEqual.
+func deepHash(value reflect.Value) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	writeDeepHash(&h, value, 0)
+	return h.Sum64()
+}
+
+// writeDeepHash writes the contents of a value to a hash, following pointers, interfaces and
+// containers up to the maximum hash depth.
+func writeDeepHash(h *maphash.Hash, value reflect.Value, depth int) {
+	if depth > maxHashDepth {
+		return
+	}
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+	if !value.IsValid() {
+		h.WriteByte(0)
+		return
+	}
+	h.WriteByte(byte(value.Kind()))
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			h.WriteByte(1)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		writeUint(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := value.Float()
+		if f == 0 {
+			f = 0 // negative zero equals zero
+		}
+		writeUint(math.Float64bits(f))
+	case reflect.Complex64, reflect.Complex128:
+		c := value.Complex()
+		r, i := real(c), imag(c)
+		if r == 0 {
+			r = 0
+		}
+		if i == 0 {
+			i = 0
+		}
+		writeUint(math.Float64bits(r))
+		writeUint(math.Float64bits(i))
+	case reflect.String:
+		h.WriteString(value.String())
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			writeDeepHash(h, value.Elem(), depth+1)
+		}
+	case reflect.Array, reflect.Slice:
+		writeUint(uint64(value.Len()))
+		for i := 0; i < value.Len(); i++ {
+			writeDeepHash(h, value.Index(i), depth+1)
+		}
+	case reflect.Map:
+		// entries are combined with addition so that the hash does not depend on map order
+		writeUint(uint64(value.Len()))
+		var sum uint64
+		iter := value.MapRange()
+		for iter.Next() {
+			var entry maphash.Hash
+			entry.SetSeed(seed)
+			writeDeepHash(&entry, iter.Key(), depth+1)
+			writeDeepHash(&entry, iter.Value(), depth+1)
+			sum += entry.Sum64()
+		}
+		writeUint(sum)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			writeDeepHash(h, value.Field(i), depth+1)
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(value.Pointer()))
+	}
+}
+
+// IdentityEquality gets an equality strategy that compares values using ==, the same equality
+// used for map keys. Values that cannot be compared using ==, such as slices held in interfaces,
+// are compared using reflect.DeepEqual instead so that comparisons never panic.
+// This is the default equality strategy for all collections.
+func IdentityEquality[T any]() Hasher[T] {
+	return identityEquality[T]{}
+}
+
+// DeepEquality gets an equality strategy that compares values using reflect.DeepEqual.
+// Pointers are equal if they point to deeply equal values.
+func DeepEquality[T any]() Hasher[T] {
+	return deepEquality[T]{}
+}
+
+// KeyEquality gets an equality strategy that considers two values equal if the supplied function
+// derives equal keys from them.
+func KeyEquality[T any, K comparable](key func(T) K) Hasher[T] {
+	return keyEquality[T, K]{key: key}
+}
+
+// EqualFunc gets an equality strategy that compares values using the supplied function.
+// Collections must compare every pair of values when using a strategy that cannot hash values,
+// prefer KeyEquality where possible.
+func EqualFunc[T any](equal func(a, b T) bool) Equaler[T] {
+	return equalFunc[T](equal)
+}
+
+// unhashed adapts an equality strategy that cannot hash values for use in hash based collections.
+// All values have the same hash, so every pair of values must be compared.
+type unhashed[T any] struct {
+	Equaler[T]
+}
+
+func (unhashed[T]) Hash(value T) uint64 {
+	return 0
+}
+
+// hasherOrDefault gets the supplied equality strategy as a Hasher, or the default strategy if it
+// is nil.
+func hasherOrDefault[T any](equality Equaler[T]) Hasher[T] {
+	if hasher, ok := equalityOrDefault(equality).(Hasher[T]); ok {
+		return hasher
+	}
+	return unhashed[T]{equality}
+}
+
+// equalityOrDefault gets the supplied equality strategy or the default strategy if it is nil.
+func equalityOrDefault[T any](equality Equaler[T]) Equaler[T] {
+	if equality == nil {
+		return identityEquality[T]{}
+	}
+	return equality
+}
+
+// matcher creates a predicate that checks if a value is equal to any of the supplied values.
+func matcher[T any](equality Equaler[T], values []T) func(T) bool {
+	equality = equalityOrDefault(equality)
+	hasher, ok := equality.(Hasher[T])
+	if !ok || len(values) < 2 {
+		return func(value T) bool {
+			for _, v := range values {
+				if equality.Equal(v, value) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	buckets := make(map[uint64][]T, len(values))
+	for _, v := range values {
+		hash := hasher.Hash(v)
+		buckets[hash] = append(buckets[hash], v)
+	}
+	return func(value T) bool {
+		for _, v := range buckets[hasher.Hash(value)] {
+			if hasher.Equal(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// membership creates a predicate that checks if a value is in the other collection. Sets are
+// checked using their own equality, the elements of any other collection are compared using the
+// supplied equality strategy.
+func membership[T any](equality Equaler[T], other CollectionOf[T]) func(T) bool {
+	if set, ok := other.(SetOf[T]); ok {
+		return func(value T) bool {
+			return set.Contains(value)
+		}
+	}
+	return matcher(equality, other.ToArray())
+}
+
+// containsAll checks if every value is equal to at least one of the supplied elements.
+func containsAll[T any](equality Equaler[T], elements []T, values []T) bool {
+	switch len(values) {
+	case 0:
+		return true
+	case 1:
+		return indexOf(equality, elements, values[0]) >= 0
+	}
+	match := matcher(equality, elements)
+	for _, value := range values {
+		if !match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexOf gets the index of the first element equal to the value or -1 if not found.
+func indexOf[T any](equality Equaler[T], elements []T, value T) int {
+	equality = equalityOrDefault(equality)
+	for index, element := range elements {
+		if equality.Equal(value, element) {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,199 @@
+package gollections_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// tagged is an element type that cannot be compared using ==.
+type tagged struct {
+	name string
+	tags []string
+}
+
+// TestIdentityEquality tests the default equality strategy.
+func TestIdentityEquality(t *testing.T) {
+	equality := gollections.IdentityEquality[any]()
+	a, b := 1, 1
+	cases := []struct {
+		x, y  any
+		equal bool
+	}{
+		{1, 1, true},
+		{1, 2, false},
+		{1, int64(1), false},
+		{"a", "a", true},
+		{nil, nil, true},
+		{&a, &a, true},
+		{&a, &b, false},
+		{[]int{1, 2}, []int{1, 2}, true},
+		{[]int{1, 2}, []int{2, 1}, false},
+		{[]int{1}, 1, false},
+		{math.NaN(), math.NaN(), false},
+	}
+	for _, c := range cases {
+		if got := equality.Equal(c.x, c.y); got != c.equal {
+			t.Fatalf("expected Equal(%v, %v) to be %t", c.x, c.y, c.equal)
+		}
+		if c.equal && equality.Hash(c.x) != equality.Hash(c.y) {
+			t.Fatalf("expected equal hashes for %v and %v", c.x, c.y)
+		}
+	}
+}
+
+// TestIdentityEqualityComparable tests that the default equality strategy compares values of
+// comparable types without reflection.
+func TestIdentityEqualityComparable(t *testing.T) {
+	type point struct {
+		x, y int
+		name string
+	}
+	points := gollections.IdentityEquality[point]()
+	integers := gollections.IdentityEquality[int]()
+	a, b := point{1, 2, "a"}, point{1, 2, "a"}
+	allocs := testing.AllocsPerRun(100, func() {
+		if !points.Equal(a, b) || points.Equal(a, point{2, 1, "a"}) || !integers.Equal(1000, 1000) {
+			t.Fatal("expected values to be compared using ==")
+		}
+	})
+	if allocs > 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+	if points.Hash(a) != points.Hash(b) {
+		t.Fatal("expected equal hashes")
+	}
+	// structs holding interfaces may hold values that cannot be compared using ==
+	type boxed struct{ value any }
+	boxes := gollections.IdentityEquality[boxed]()
+	if !boxes.Equal(boxed{[]int{1}}, boxed{[]int{1}}) || boxes.Equal(boxed{[]int{1}}, boxed{1}) {
+		t.Fatal("expected boxed slices to be compared deeply")
+	}
+}
+
+// TestDeepEquality tests comparing values using reflect.DeepEqual.
+func TestDeepEquality(t *testing.T) {
+	equality := gollections.DeepEquality[*tagged]()
+	x := &tagged{name: "a", tags: []string{"b"}}
+	y := &tagged{name: "a", tags: []string{"b"}}
+	z := &tagged{name: "a", tags: []string{"c"}}
+	if !equality.Equal(x, y) || equality.Equal(x, z) {
+		t.Fatal("expected pointers to deeply equal values to be equal")
+	}
+	if equality.Hash(x) != equality.Hash(y) {
+		t.Fatal("expected equal hashes for deeply equal values")
+	}
+	maps := gollections.DeepEquality[map[string]int]()
+	m1, m2 := map[string]int{}, map[string]int{}
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		m1[key] = i
+		m2[key] = i
+	}
+	if !maps.Equal(m1, m2) || maps.Hash(m1) != maps.Hash(m2) {
+		t.Fatal("expected equal maps to have equal hashes")
+	}
+	zero := gollections.DeepEquality[float64]()
+	if zero.Hash(0) != zero.Hash(math.Copysign(0, -1)) {
+		t.Fatal("expected zero and negative zero to have equal hashes")
+	}
+}
+
+// TestKeyEquality tests comparing values by a derived key.
+func TestKeyEquality(t *testing.T) {
+	equality := gollections.KeyEquality(strings.ToLower)
+	if !equality.Equal("Go", "gO") || equality.Equal("Go", "C") {
+		t.Fatal("expected case insensitive equality")
+	}
+	if equality.Hash("Go") != equality.Hash("GO") {
+		t.Fatal("expected equal hashes for equal keys")
+	}
+}
+
+// TestListEquality tests that lists answer Contains, Remove and IndexOf consistently for elements
+// that cannot be compared using ==.
+func TestListEquality(t *testing.T) {
+	lists := map[string]func(...gollections.Option[tagged]) gollections.ListOf[tagged]{
+		"linked": gollections.NewLinkedListOf[tagged],
+		"array":  gollections.NewArrayListOf[tagged],
+		"deque": func(options ...gollections.Option[tagged]) gollections.ListOf[tagged] {
+			return gollections.NewArrayDequeOfCapacity(0, options...)
+		},
+	}
+	for name, newList := range lists {
+		list := newList()
+		list.Add(tagged{"a", []string{"x"}}, tagged{"b", nil}, tagged{"a", []string{"x"}})
+		value := tagged{"a", []string{"x"}}
+		if !list.Contains(value) || list.IndexOf(value) != 0 {
+			t.Fatalf("%s: expected list to contain %v at index 0", name, value)
+		}
+		if list.Contains(tagged{"a", []string{"y"}}) || list.IndexOf(tagged{"c", nil}) != -1 {
+			t.Fatalf("%s: expected list not to contain missing values", name)
+		}
+		list.Remove(value)
+		if list.Size() != 1 || list.Contains(value) || list.IndexOf(value) != -1 {
+			t.Fatalf("%s: expected all equal values to be removed", name)
+		}
+		// custom equality
+		list = newList(gollections.WithEquality(gollections.EqualFunc(func(a, b tagged) bool {
+			return a.name == b.name
+		})))
+		list.Add(tagged{"a", nil}, tagged{"b", nil})
+		if !list.Contains(tagged{"b", []string{"z"}}) || list.IndexOf(tagged{"b", []string{"z"}}) != 1 {
+			t.Fatalf("%s: expected values with the same name to be equal", name)
+		}
+		list.Remove(tagged{"a", []string{"z"}})
+		if list.Size() != 1 {
+			t.Fatalf("%s: expected size 1, got %d", name, list.Size())
+		}
+	}
+}
+
+// TestSetEquality tests sets that use a custom equality strategy.
+func TestSetEquality(t *testing.T) {
+	sets := map[string]func(...gollections.Option[string]) gollections.SetOf[string]{
+		"hash":   gollections.NewHashSetOf[string],
+		"linked": gollections.NewLinkedHashSetOf[string],
+	}
+	for name, newSet := range sets {
+		for _, equality := range []gollections.Equaler[string]{
+			gollections.KeyEquality(strings.ToLower),
+			gollections.EqualFunc(strings.EqualFold),
+		} {
+			set := newSet(gollections.WithEquality(equality))
+			set.Add("Go", "GO", "Rust")
+			if set.Size() != 2 || !set.Contains("go", "rust") {
+				t.Fatalf("%s: expected case insensitive set, got %v", name, set.ToArray())
+			}
+			other := newSet()
+			other.Add("go", "c")
+			if union := set.Union(other); union.Size() != 3 {
+				t.Fatalf("%s: expected union to keep equality, got %v", name, union.ToArray())
+			}
+			set.Remove("RUST")
+			if set.Size() != 1 {
+				t.Fatalf("%s: expected size 1, got %d", name, set.Size())
+			}
+			for iterator := set.Iterator(); iterator.HasNext(); {
+				iterator.Next()
+				if err := iterator.Remove(); err != nil {
+					t.Fatalf("%s: unexpected error: %v", name, err)
+				}
+			}
+			if !set.IsEmpty() || set.Contains("go") {
+				t.Fatalf("%s: expected empty set", name)
+			}
+		}
+	}
+	// values that cannot be compared using ==
+	for _, set := range []gollections.SetOf[[]int]{
+		gollections.NewHashSetOf[[]int](),
+		gollections.NewLinkedHashSetOf[[]int](),
+	} {
+		set.Add([]int{1, 2}, []int{1, 2}, []int{2, 1})
+		if set.Size() != 2 || !set.Contains([]int{2, 1}) {
+			t.Fatalf("expected two distinct slices, got %v", set.ToArray())
+		}
+	}
+}
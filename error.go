@@ -3,9 +3,30 @@ package gollections
 import "errors"
 
 var (
+	// ErrCapacityExceeded the collection cannot hold any more elements.
+	ErrCapacityExceeded = errors.New("capacity exceeded")
+
+	// ErrClosed the collection has been closed.
+	ErrClosed = errors.New("closed")
+
+	// ErrConcurrentModification the collection was modified while being iterated.
+	ErrConcurrentModification = errors.New("concurrent modification")
+
 	// ErrIndexOutOfBounds the supplied index was invalid for this list.
 	ErrIndexOutOfBounds = errors.New("index out of bounds")
 
+	// ErrIllegalState the operation is not valid in the current state.
+	ErrIllegalState = errors.New("illegal state")
+
+	// ErrInvalidArgument the supplied argument is not valid for this operation.
+	ErrInvalidArgument = errors.New("invalid argument")
+
 	// ErrNoSuchElement the polled element does not exist.
 	ErrNoSuchElement = errors.New("no such element")
+
+	// ErrTimeout the operation did not complete before its timeout elapsed.
+	ErrTimeout = errors.New("timeout")
+
+	// ErrUnsupportedOperation the operation is not supported by this implementation.
+	ErrUnsupportedOperation = errors.New("unsupported operation")
 )
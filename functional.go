@@ -0,0 +1,377 @@
+package gollections
+
+import "iter"
+
+// A Pair holds two values, e.g. the corresponding elements of two zipped collections.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// A configured collection is a collection provided by this package that can describe its
+// configuration to the functions of this package.
+type configured[T any] interface {
+	CollectionOf[T]
+	// equalityStrategy gets the equality strategy of the collection, or nil if the collection
+	// uses the default strategy.
+	equalityStrategy() Equaler[T]
+	// emptyCollection initializes an empty collection of the same kind and configuration. A
+	// collection with a capacity only keeps its capacity if bounded is true.
+	emptyCollection(bounded bool) CollectionOf[T]
+}
+
+// equalityOf gets the equality strategy of a collection provided by this package, or nil if the
+// collection uses the default strategy.
+func equalityOf[T any](c CollectionOf[T]) Equaler[T] {
+	if c, ok := c.(configured[T]); ok {
+		return c.equalityStrategy()
+	}
+	return nil
+}
+
+// emptyLike initializes an empty collection of the same kind and configuration as the supplied
+// collection. The result only keeps the capacity of the collection if bounded is true, so results
+// that may hold more elements than the collection must not be bounded. Sets of other packages are
+// emptied using their own set operations and any other kind of collection is replaced by an
+// ArrayDeque, which implements every collection interface of this package.
+func emptyLike[T any](c CollectionOf[T], bounded bool) CollectionOf[T] {
+	if c, ok := c.(configured[T]); ok {
+		return c.emptyCollection(bounded)
+	}
+	if s, ok := c.(SetOf[T]); ok {
+		return s.Difference(s)
+	}
+	return &ArrayDeque[T]{}
+}
+
+// emptyOf initializes an empty collection of type C with the same configuration as the supplied
+// collection, and the same capacity if bounded is true. Returns an unsupported operation error if
+// C is a collection type defined outside of this package that emptyLike cannot create.
+func emptyOf[T any, C CollectionOf[T]](c C, bounded bool) (C, error) {
+	result, ok := emptyLike[T](c, bounded).(C)
+	if !ok {
+		return result, ErrUnsupportedOperation
+	}
+	return result, nil
+}
+
+// like initializes an empty collection of the same kind as the supplied collection that holds
+// elements of another type. Sets keep their kind, sorted sets become a LinkedHashSet that keeps
+// their order, and any other kind of collection is replaced by an ArrayDeque. A fixed size
+// ArrayDeque only keeps its size if bounded is true. Methods cannot have type parameters, so
+// unlike emptyLike the kind is found with a type switch.
+func like[T, U any](c CollectionOf[T], bounded bool) CollectionOf[U] {
+	switch c := c.(type) {
+	case *LinkedList[T]:
+		return &LinkedList[U]{}
+	case *ArrayList[T]:
+		return &ArrayList[U]{}
+	case *ArrayDeque[T]:
+		if bounded {
+			return newArrayDeque[U](c.maxSize, c.maxSize, nil)
+		}
+		return &ArrayDeque[U]{}
+	case *HashSet[T]:
+		return &HashSet[U]{}
+	case SetOf[T]:
+		return &LinkedHashSet[U]{}
+	}
+	return &ArrayDeque[U]{}
+}
+
+// Filter gets a new collection of the same kind as the supplied collection holding the elements
+// that match the predicate. Returns an unsupported operation error if C is not an interface, such
+// as ListOf, or a collection type provided by this package.
+func Filter[T any, C CollectionOf[T]](c C, predicate func(T) bool) (C, error) {
+	result, err := emptyOf[T](c, true)
+	if err != nil {
+		return result, err
+	}
+	for value := range FilterSeq(c.All(), predicate) {
+		result.Add(value)
+	}
+	return result, nil
+}
+
+// Map gets a new collection holding the result of applying the mapper to each element of the
+// supplied collection. The result is of the same kind as the supplied collection where the kind
+// does not depend on the element type, e.g. a LinkedList is mapped to a LinkedList while a
+// PriorityQueue is mapped to an ArrayDeque.
+func Map[T, U any](c CollectionOf[T], mapper func(T) U) CollectionOf[U] {
+	result := like[T, U](c, true)
+	for value := range MapSeq(c.All(), mapper) {
+		result.Add(value)
+	}
+	return result
+}
+
+// MapList gets a new list of the same kind as the supplied list holding the result of applying
+// the mapper to each element.
+func MapList[T, U any](l ListOf[T], mapper func(T) U) ListOf[U] {
+	result, ok := like[T, U](l, true).(ListOf[U])
+	if !ok {
+		result = &ArrayDeque[U]{}
+	}
+	for value := range MapSeq(l.All(), mapper) {
+		result.Add(value)
+	}
+	return result
+}
+
+// FlatMap gets a new collection holding the elements of each sequence returned by the mapper.
+// The result is of the same kind as for Map, except that a fixed size ArrayDeque is mapped to an
+// ArrayDeque without a fixed size, as the result may hold more elements.
+func FlatMap[T, U any](c CollectionOf[T], mapper func(T) iter.Seq[U]) CollectionOf[U] {
+	result := like[T, U](c, false)
+	for value := range FlatMapSeq(c.All(), mapper) {
+		result.Add(value)
+	}
+	return result
+}
+
+// Reduce combines the elements of a collection from first to last using the supplied function.
+// Returns an error if the collection is empty.
+func Reduce[T any](c CollectionOf[T], combine func(T, T) T) (T, error) {
+	var result T
+	first := true
+	for value := range c.All() {
+		if first {
+			result, first = value, false
+			continue
+		}
+		result = combine(result, value)
+	}
+	if first {
+		return result, ErrNoSuchElement
+	}
+	return result, nil
+}
+
+// Fold combines the elements of a collection from first to last into an accumulator that starts
+// at the initial value.
+func Fold[T, A any](c CollectionOf[T], initial A, combine func(A, T) A) A {
+	result := initial
+	for value := range c.All() {
+		result = combine(result, value)
+	}
+	return result
+}
+
+// AnyMatch checks if any element of the collection matches the predicate.
+func AnyMatch[T any](c CollectionOf[T], predicate func(T) bool) bool {
+	for value := range c.All() {
+		if predicate(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch checks if every element of the collection matches the predicate.
+func AllMatch[T any](c CollectionOf[T], predicate func(T) bool) bool {
+	for value := range c.All() {
+		if !predicate(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// NoneMatch checks if no element of the collection matches the predicate.
+func NoneMatch[T any](c CollectionOf[T], predicate func(T) bool) bool {
+	return !AnyMatch(c, predicate)
+}
+
+// Find gets the first element of the collection that matches the predicate.
+// Returns an error if no such element exists.
+func Find[T any](c CollectionOf[T], predicate func(T) bool) (T, error) {
+	for value := range c.All() {
+		if predicate(value) {
+			return value, nil
+		}
+	}
+	var zero T
+	return zero, ErrNoSuchElement
+}
+
+// GroupBy groups the elements of a collection by the key derived from each element. Each group is
+// a new collection of the same kind as the supplied collection. Returns an unsupported operation
+// error for the same kinds of collection as Filter.
+func GroupBy[T any, C CollectionOf[T], K comparable](c C, key func(T) K) (map[K]C, error) {
+	if _, err := emptyOf[T](c, true); err != nil {
+		return nil, err
+	}
+	groups := map[K]C{}
+	for value := range c.All() {
+		k := key(value)
+		group, ok := groups[k]
+		if !ok {
+			group, _ = emptyOf[T](c, true)
+			groups[k] = group
+		}
+		group.Add(value)
+	}
+	return groups, nil
+}
+
+// Partition splits a collection into new collections of the same kind holding the elements that
+// match and do not match the predicate. Returns an unsupported operation error for the same kinds
+// of collection as Filter.
+func Partition[T any, C CollectionOf[T]](c C, predicate func(T) bool) (matched, unmatched C, err error) {
+	if matched, err = emptyOf[T](c, true); err != nil {
+		return matched, unmatched, err
+	}
+	unmatched, _ = emptyOf[T](c, true)
+	for value := range c.All() {
+		if predicate(value) {
+			matched.Add(value)
+		} else {
+			unmatched.Add(value)
+		}
+	}
+	return matched, unmatched, nil
+}
+
+// Distinct gets a new collection of the same kind holding the first occurrence of each element.
+// Elements are compared using the equality strategy of the collection. Returns an unsupported
+// operation error for the same kinds of collection as Filter.
+func Distinct[T any, C CollectionOf[T]](c C) (C, error) {
+	result, err := emptyOf[T](c, true)
+	if err != nil {
+		return result, err
+	}
+	for value := range DistinctSeq(c.All(), equalityOf[T](c)) {
+		result.Add(value)
+	}
+	return result, nil
+}
+
+// Zip gets a list of pairs of the corresponding elements of two collections. The list is as long
+// as the shorter collection. The list is a LinkedList if the first collection is a LinkedList,
+// and an ArrayList otherwise.
+func Zip[T, U any](a CollectionOf[T], b CollectionOf[U]) ListOf[Pair[T, U]] {
+	var result ListOf[Pair[T, U]] = &ArrayList[Pair[T, U]]{}
+	if _, ok := a.(*LinkedList[T]); ok {
+		result = &LinkedList[Pair[T, U]]{}
+	}
+	for pair := range ZipSeq(a.All(), b.All()) {
+		result.Add(pair)
+	}
+	return result
+}
+
+// Chunk splits a collection into new collections of the same kind holding at most the specified
+// number of consecutive elements. Only the last chunk may be smaller. Chunks of a collection with
+// a capacity, such as a fixed size ArrayDeque, do not keep the capacity, as a chunk may be larger
+// than the collection. Returns an invalid argument error if the size is less than one, and an
+// unsupported operation error for the same kinds of collection as Filter.
+func Chunk[T any, C CollectionOf[T]](c C, size int) (ListOf[C], error) {
+	if size < 1 {
+		return nil, ErrInvalidArgument
+	}
+	if _, err := emptyOf[T](c, false); err != nil {
+		return nil, err
+	}
+	chunks := &ArrayList[C]{}
+	var chunk C
+	for value := range c.All() {
+		if chunks.IsEmpty() || chunk.Size() == size {
+			chunk, _ = emptyOf[T](c, false)
+			chunks.Add(chunk)
+		}
+		chunk.Add(value)
+	}
+	return chunks, nil
+}
+
+// FilterSeq creates a sequence of the values of the supplied sequence that match the predicate.
+func FilterSeq[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range seq {
+			if predicate(value) && !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// MapSeq creates a sequence of the result of applying the mapper to each value of the supplied
+// sequence.
+func MapSeq[T, U any](seq iter.Seq[T], mapper func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for value := range seq {
+			if !yield(mapper(value)) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMapSeq creates a sequence of the values of each sequence returned by the mapper.
+func FlatMapSeq[T, U any](seq iter.Seq[T], mapper func(T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for value := range seq {
+			for mapped := range mapper(value) {
+				if !yield(mapped) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DistinctSeq creates a sequence of the first occurrence of each value of the supplied sequence.
+// Values are compared using the supplied equality strategy, or IdentityEquality if it is nil.
+func DistinctSeq[T any](seq iter.Seq[T], equality Equaler[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := &HashSet[T]{equality: hasherOrDefault(equality)}
+		for value := range seq {
+			if seen.Contains(value) {
+				continue
+			}
+			seen.Add(value)
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// ZipSeq creates a sequence of pairs of the corresponding values of two sequences. The sequence
+// ends when either of the supplied sequences ends.
+func ZipSeq[T, U any](a iter.Seq[T], b iter.Seq[U]) iter.Seq[Pair[T, U]] {
+	return func(yield func(Pair[T, U]) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for first := range a {
+			second, ok := next()
+			if !ok || !yield(Pair[T, U]{First: first, Second: second}) {
+				return
+			}
+		}
+	}
+}
+
+// ChunkSeq creates a sequence of slices holding at most the specified number of consecutive
+// values of the supplied sequence. Only the last slice may be smaller. Returns an invalid argument
+// error if the size is less than one.
+func ChunkSeq[T any](seq iter.Seq[T], size int) (iter.Seq[[]T], error) {
+	if size < 1 {
+		return nil, ErrInvalidArgument
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for value := range seq {
+			chunk = append(chunk, value)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}, nil
+}
@@ -0,0 +1,256 @@
+package gollections_test
+
+import (
+	"cmp"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// isEven checks if an integer is even.
+func isEven(value int) bool {
+	return value%2 == 0
+}
+
+// TestFilter tests that filtering keeps the kind and configuration of a collection.
+func TestFilter(t *testing.T) {
+	list := gollections.NewLinkedListOf[int]()
+	list.Add(1, 2, 3, 4, 5, 6)
+	evens, err := gollections.Filter(list, isEven)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := evens.(*gollections.LinkedList[int]); !ok {
+		t.Fatalf("expected linked list, got %T", evens)
+	}
+	if got := evens.ToArray(); !reflect.DeepEqual([]int{2, 4, 6}, got) {
+		t.Fatalf("expected [2 4 6], got %v", got)
+	}
+	array := gollections.NewArrayListOfCapacity[int](4)
+	array.Add(1, 2, 3)
+	filtered, _ := gollections.Filter(array, isEven)
+	if filtered.Size() != 1 || array.Size() != 3 {
+		t.Fatalf("expected a new list holding 2, got %v", filtered.ToArray())
+	}
+	set := gollections.NewHashSetOf(gollections.WithEquality(gollections.KeyEquality(strings.ToLower)))
+	set.Add("Go", "Rust", "C")
+	short, _ := gollections.Filter(set, func(value string) bool { return len(value) < 3 })
+	short.Add("GO")
+	if short.Size() != 2 || !short.Contains("go", "c") {
+		t.Fatalf("expected set to keep its equality, got %v", short.ToArray())
+	}
+	queue := gollections.NewPriorityQueueOfValues(cmp.Compare[int], []int{5, 2, 8, 4})
+	evenQueue, _ := gollections.Filter(queue, isEven)
+	if first, err := evenQueue.PopFirst(); err != nil || first != 2 {
+		t.Fatalf("expected 2, got %d, err: %v", first, err)
+	}
+	// collection types of other packages cannot be created
+	opaque := opaqueList{gollections.NewArrayListOf[int]()}
+	if _, err := gollections.Filter(opaque, isEven); err != gollections.ErrUnsupportedOperation {
+		t.Fatalf("expected unsupported operation error, got %v", err)
+	}
+	if _, err := gollections.Filter[int, gollections.ListOf[int]](opaque, isEven); err != nil {
+		t.Fatalf("expected no error for an interface, got %v", err)
+	}
+}
+
+// TestMap tests mapping collections to collections of another element type.
+func TestMap(t *testing.T) {
+	list := gollections.NewArrayListOf[int]()
+	list.Add(1, 2, 3)
+	strs := gollections.MapList(list, func(value int) string { return strings.Repeat("a", value) })
+	if _, ok := strs.(*gollections.ArrayList[string]); !ok {
+		t.Fatalf("expected array list, got %T", strs)
+	}
+	if got := strs.ToArray(); !reflect.DeepEqual([]string{"a", "aa", "aaa"}, got) {
+		t.Fatalf("expected [a aa aaa], got %v", got)
+	}
+	set := gollections.NewHashSetOf[int]()
+	set.Add(1, 2, 3, 4)
+	parity := gollections.Map(set, isEven)
+	if _, ok := parity.(gollections.SetOf[bool]); !ok || parity.Size() != 2 {
+		t.Fatalf("expected a set of two values, got %T %v", parity, parity.ToArray())
+	}
+	words := gollections.NewLinkedListOf[string]()
+	words.Add("ab", "", "c")
+	letters := gollections.FlatMap(words, func(word string) iter.Seq[string] {
+		return slices.Values(strings.Split(word, ""))
+	})
+	if got := letters.ToArray(); !reflect.DeepEqual([]string{"a", "b", "c"}, got) {
+		t.Fatalf("expected [a b c], got %v", got)
+	}
+	// flat mapping may grow the result beyond the size of a fixed size deque
+	deque := gollections.NewFixedArrayDequeOf[int](2)
+	deque.Add(1, 2)
+	tripled := gollections.FlatMap(deque, func(value int) iter.Seq[int] {
+		return slices.Values([]int{value, value, value})
+	})
+	if got := tripled.ToArray(); !reflect.DeepEqual([]int{1, 1, 1, 2, 2, 2}, got) {
+		t.Fatalf("expected [1 1 1 2 2 2], got %v", got)
+	}
+}
+
+// TestReduce tests combining the elements of collections.
+func TestReduce(t *testing.T) {
+	list := gollections.NewLinkedListOf[int]()
+	if _, err := gollections.Reduce(list, func(a, b int) int { return a + b }); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	list.Add(1, 2, 3, 4)
+	if sum, err := gollections.Reduce(list, func(a, b int) int { return a + b }); err != nil || sum != 10 {
+		t.Fatalf("expected 10, got %d, err: %v", sum, err)
+	}
+	joined := gollections.Fold(list, "", func(acc string, value int) string {
+		return acc + strings.Repeat("x", value)
+	})
+	if len(joined) != 10 {
+		t.Fatalf("expected 10 characters, got %q", joined)
+	}
+}
+
+// TestMatch tests the matching and search functions.
+func TestMatch(t *testing.T) {
+	list := gollections.NewArrayListOf[int]()
+	list.Add(1, 3, 4, 5)
+	if !gollections.AnyMatch(list, isEven) || gollections.AllMatch(list, isEven) ||
+		gollections.NoneMatch(list, isEven) {
+		t.Fatal("expected exactly some elements to be even")
+	}
+	if found, err := gollections.Find(list, isEven); err != nil || found != 4 {
+		t.Fatalf("expected 4, got %d, err: %v", found, err)
+	}
+	if _, err := gollections.Find(list, func(value int) bool { return value > 5 }); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	empty := gollections.NewArrayListOf[int]()
+	if gollections.AnyMatch(empty, isEven) || !gollections.AllMatch(empty, isEven) ||
+		!gollections.NoneMatch(empty, isEven) {
+		t.Fatal("unexpected result for empty collection")
+	}
+}
+
+// TestGroupBy tests grouping and partitioning collections.
+func TestGroupBy(t *testing.T) {
+	list := gollections.NewLinkedListOf[string]()
+	list.Add("apple", "avocado", "banana", "blueberry", "cherry")
+	groups, err := gollections.GroupBy(list, func(value string) byte { return value[0] })
+	if err != nil || len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(groups))
+	}
+	if got := groups['b'].ToArray(); !reflect.DeepEqual([]string{"banana", "blueberry"}, got) {
+		t.Fatalf("expected [banana blueberry], got %v", got)
+	}
+	numbers := gollections.NewArrayDequeOf[int]()
+	numbers.Add(1, 2, 3, 4, 5)
+	evens, odds, err := gollections.Partition(numbers, isEven)
+	if err != nil || !reflect.DeepEqual([]int{2, 4}, evens.ToArray()) ||
+		!reflect.DeepEqual([]int{1, 3, 5}, odds.ToArray()) {
+		t.Fatalf("expected [2 4] and [1 3 5], got %v and %v", evens.ToArray(), odds.ToArray())
+	}
+}
+
+// TestDistinct tests removing duplicate elements.
+func TestDistinct(t *testing.T) {
+	list := gollections.NewArrayListOf(gollections.WithEquality(gollections.KeyEquality(strings.ToLower)))
+	list.Add("b", "A", "a", "B", "c")
+	if got, _ := gollections.Distinct(list); !reflect.DeepEqual([]string{"b", "A", "c"}, got.ToArray()) {
+		t.Fatalf("expected [b A c], got %v", got.ToArray())
+	}
+	slices := gollections.NewLinkedListOf[[]int]()
+	slices.Add([]int{1}, []int{1}, []int{2})
+	if distinct, _ := gollections.Distinct(slices); distinct.Size() != 2 {
+		t.Fatalf("expected size 2, got %d", distinct.Size())
+	}
+}
+
+// TestZip tests pairing the elements of two collections.
+func TestZip(t *testing.T) {
+	a := gollections.NewLinkedListOf[int]()
+	a.Add(1, 2, 3)
+	b := gollections.NewArrayListOf[string]()
+	b.Add("a", "b")
+	zipped := gollections.Zip(a, b)
+	expected := []gollections.Pair[int, string]{{1, "a"}, {2, "b"}}
+	if got := zipped.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if _, ok := zipped.(*gollections.LinkedList[gollections.Pair[int, string]]); !ok {
+		t.Fatalf("expected linked list, got %T", zipped)
+	}
+}
+
+// TestChunk tests splitting a collection into chunks.
+func TestChunk(t *testing.T) {
+	list := gollections.NewArrayListOf[int]()
+	list.Add(1, 2, 3, 4, 5)
+	chunks, err := gollections.Chunk(list, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got := [][]int{}
+	for chunk := range chunks.All() {
+		got = append(got, chunk.ToArray())
+	}
+	if expected := [][]int{{1, 2}, {3, 4}, {5}}; !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if chunks, _ := gollections.Chunk(gollections.NewArrayListOf[int](), 3); !chunks.IsEmpty() {
+		t.Fatalf("expected no chunks, got %d", chunks.Size())
+	}
+	if _, err := gollections.Chunk(list, 0); err != gollections.ErrInvalidArgument {
+		t.Fatalf("expected invalid argument error for chunk size 0, got %v", err)
+	}
+	if _, err := gollections.ChunkSeq(list.All(), 0); err != gollections.ErrInvalidArgument {
+		t.Fatalf("expected invalid argument error for chunk size 0, got %v", err)
+	}
+	// chunks of a fixed size deque may be larger than the deque
+	deque := gollections.NewFixedArrayDequeOf[int](2)
+	deque.Add(1, 2)
+	fixed, err := gollections.Chunk(deque, 3)
+	if err != nil || fixed.Size() != 1 {
+		t.Fatalf("expected a single chunk, got %v, err: %v", fixed, err)
+	}
+	chunk, _ := fixed.Get(0)
+	if chunk.Add(3); !reflect.DeepEqual([]int{1, 2, 3}, chunk.ToArray()) {
+		t.Fatalf("expected [1 2 3], got %v", chunk.ToArray())
+	}
+}
+
+// TestSeq tests the lazy sequence functions.
+func TestSeq(t *testing.T) {
+	values := slices.Values([]int{1, 2, 2, 3, 4, 4, 5})
+	evens := gollections.FilterSeq(values, isEven)
+	squares := gollections.MapSeq(evens, func(value int) int { return value * value })
+	if got := slices.Collect(squares); !reflect.DeepEqual([]int{4, 4, 16, 16}, got) {
+		t.Fatalf("expected [4 4 16 16], got %v", got)
+	}
+	if got := slices.Collect(gollections.DistinctSeq(values, nil)); !reflect.DeepEqual([]int{1, 2, 3, 4, 5}, got) {
+		t.Fatalf("expected [1 2 3 4 5], got %v", got)
+	}
+	chunked, _ := gollections.ChunkSeq(values, 3)
+	chunks := slices.Collect(chunked)
+	if expected := [][]int{{1, 2, 2}, {3, 4, 4}, {5}}; !reflect.DeepEqual(expected, chunks) {
+		t.Fatalf("expected %v, got %v", expected, chunks)
+	}
+	pairs := slices.Collect(gollections.ZipSeq(values, slices.Values([]string{"a", "b"})))
+	if len(pairs) != 2 || pairs[1].First != 2 || pairs[1].Second != "b" {
+		t.Fatalf("unexpected pairs: %v", pairs)
+	}
+	// sequences stop early
+	for range gollections.FlatMapSeq(values, func(value int) iter.Seq[int] {
+		return slices.Values([]int{value, value})
+	}) {
+		break
+	}
+	// linked sets keep their order when mapped
+	set := gollections.NewLinkedHashSetOf[int]()
+	set.Add(3, 1, 2)
+	mapped := gollections.Map(set, func(value int) int { return -value }).ToArray()
+	if !reflect.DeepEqual([]int{-3, -1, -2}, mapped) {
+		t.Fatalf("expected [-3 -1 -2], got %v", mapped)
+	}
+}
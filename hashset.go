@@ -0,0 +1,244 @@
+package gollections
+
+import (
+	"iter"
+	"slices"
+)
+
+// HashSet is an implementation of a set backed by a hash table.
+// Elements are unordered; adding, removing and checking for an element are O(1).
+// Elements are hashed and compared using the equality strategy of the set, values that cannot be
+// compared using == may be held by a set that uses DeepEquality or KeyEquality.
+// Elements are held in a slice that the hash table indexes, so that iterating over the set does
+// not copy it. Removing an element moves the last element into its position.
+// The zero value is an empty set ready to use.
+type HashSet[T any] struct {
+	// positions holds the positions of the elements with each hash
+	positions map[uint64][]int
+	values    []T
+	hashes    []uint64
+	equality  Hasher[T]
+	modCount  int
+}
+
+// equalityStrategy gets the equality strategy of the set.
+func (s *HashSet[T]) equalityStrategy() Equaler[T] {
+	return s.equality
+}
+
+// emptyCollection initializes an empty set with the same configuration.
+func (s *HashSet[T]) emptyCollection(bool) CollectionOf[T] {
+	return s.empty()
+}
+
+// hasher gets the equality strategy of the set.
+func (s *HashSet[T]) hasher() Hasher[T] {
+	if s.equality == nil {
+		return identityEquality[T]{}
+	}
+	return s.equality
+}
+
+// find gets the hash of a value and the position of the element equal to it.
+// The position is -1 if the set does not contain the value.
+func (s *HashSet[T]) find(value T) (uint64, int) {
+	hasher := s.hasher()
+	hash := hasher.Hash(value)
+	for _, position := range s.positions[hash] {
+		if hasher.Equal(s.values[position], value) {
+			return hash, position
+		}
+	}
+	return hash, -1
+}
+
+// move changes the position of the element with the supplied hash in the index of the set.
+func (s *HashSet[T]) move(hash uint64, from, to int) {
+	bucket := s.positions[hash]
+	i := slices.Index(bucket, from)
+	if to < 0 {
+		if len(bucket) == 1 {
+			delete(s.positions, hash)
+		} else {
+			s.positions[hash] = slices.Delete(bucket, i, i+1)
+		}
+		return
+	}
+	bucket[i] = to
+}
+
+// removeAt removes the element at the specified position, moving the last element into its
+// position.
+func (s *HashSet[T]) removeAt(position int) {
+	s.move(s.hashes[position], position, -1)
+	last := len(s.values) - 1
+	if position != last {
+		s.move(s.hashes[last], last, position)
+		s.values[position] = s.values[last]
+		s.hashes[position] = s.hashes[last]
+	}
+	var zero T
+	s.values[last] = zero
+	s.values = s.values[:last]
+	s.hashes = s.hashes[:last]
+	s.modCount++
+}
+
+// empty initializes a new set with the same equality strategy as this set.
+func (s *HashSet[T]) empty() *HashSet[T] {
+	return &HashSet[T]{equality: s.equality}
+}
+
+// Add adds new elements to the set.
+// Values that are already in the set are ignored.
+func (s *HashSet[T]) Add(values ...T) {
+	if s.positions == nil {
+		s.positions = map[uint64][]int{}
+	}
+	for _, value := range values {
+		if hash, i := s.find(value); i < 0 {
+			s.positions[hash] = append(s.positions[hash], len(s.values))
+			s.values = append(s.values, value)
+			s.hashes = append(s.hashes, hash)
+			s.modCount++
+		}
+	}
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+// The sequence panics if the collection is structurally modified during iteration.
+func (s *HashSet[T]) All() iter.Seq[T] {
+	return forward(s.Iterator)
+}
+
+// Clear removes all elements from the collection.
+func (s *HashSet[T]) Clear() {
+	s.positions = map[uint64][]int{}
+	s.values = nil
+	s.hashes = nil
+	s.modCount++
+}
+
+// Contains checks if the collection contains all specified values.
+func (s *HashSet[T]) Contains(values ...T) bool {
+	for _, value := range values {
+		if _, i := s.find(value); i < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (s *HashSet[T]) IsEmpty() bool {
+	return len(s.values) == 0
+}
+
+// Iterator gets an iterator over the elements of the collection.
+func (s *HashSet[T]) Iterator() Iterator[T] {
+	return &hashSetIterator[T]{set: s, expected: s.modCount}
+}
+
+// Remove removes all specified values from the collection.
+func (s *HashSet[T]) Remove(values ...T) {
+	for _, value := range values {
+		if _, i := s.find(value); i >= 0 {
+			s.removeAt(i)
+		}
+	}
+}
+
+// Size gets the number of elements in the collection.
+func (s *HashSet[T]) Size() int {
+	return len(s.values)
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (s *HashSet[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, s.ToArray())
+}
+
+// ToArray gets an array representation of the collection.
+func (s *HashSet[T]) ToArray() []T {
+	return append(make([]T, 0, len(s.values)), s.values...)
+}
+
+// Difference gets a set of the elements in this set that are not in the other set.
+func (s *HashSet[T]) Difference(other SetOf[T]) SetOf[T] {
+	return difference(s.empty(), s, other)
+}
+
+// Intersection gets a set of the elements in both this set and the other set.
+func (s *HashSet[T]) Intersection(other SetOf[T]) SetOf[T] {
+	return intersection(s.empty(), s, other)
+}
+
+// IsSubsetOf checks if every element in this set is also in the other set.
+func (s *HashSet[T]) IsSubsetOf(other SetOf[T]) bool {
+	return isSubset[T](s, other)
+}
+
+// IsSupersetOf checks if every element in the other set is also in this set.
+func (s *HashSet[T]) IsSupersetOf(other SetOf[T]) bool {
+	return isSubset(other, SetOf[T](s))
+}
+
+// SymmetricDifference gets a set of the elements in exactly one of this set and the other set.
+func (s *HashSet[T]) SymmetricDifference(other SetOf[T]) SetOf[T] {
+	return symmetricDifference(s.empty(), s, other)
+}
+
+// Union gets a set of the elements in either this set or the other set.
+func (s *HashSet[T]) Union(other SetOf[T]) SetOf[T] {
+	return union(s.empty(), s, other)
+}
+
+// hashSetIterator iterates over the elements of a hash set by their position.
+type hashSetIterator[T any] struct {
+	set      *HashSet[T]
+	index    int
+	removed  bool
+	expected int
+}
+
+func (i *hashSetIterator[T]) HasNext() bool {
+	return i.index < len(i.set.values)
+}
+
+func (i *hashSetIterator[T]) Next() (T, error) {
+	var zero T
+	if i.set.modCount != i.expected {
+		return zero, ErrConcurrentModification
+	}
+	if !i.HasNext() {
+		return zero, ErrNoSuchElement
+	}
+	i.index++
+	i.removed = false
+	return i.set.values[i.index-1], nil
+}
+
+func (i *hashSetIterator[T]) Remove() error {
+	if i.set.modCount != i.expected {
+		return ErrConcurrentModification
+	}
+	if i.index == 0 || i.removed {
+		return ErrIllegalState
+	}
+	// the last element moves into the position of the removed element, which is visited next
+	i.index--
+	i.set.removeAt(i.index)
+	i.removed = true
+	i.expected = i.set.modCount
+	return nil
+}
+
+// NewHashSetOf initializes a set backed by a hash table.
+// An equality strategy that is not a Hasher is supported, but every pair of elements must then be
+// compared.
+func NewHashSetOf[T any](options ...Option[T]) SetOf[T] {
+	return &HashSet[T]{
+		positions: map[uint64][]int{},
+		equality:  hasherOrDefault(newOptions(options).equality),
+	}
+}
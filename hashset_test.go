@@ -0,0 +1,64 @@
+package gollections_test
+
+import (
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the HashSet as an implementation of Set.
+func TestHashSet(t *testing.T) {
+	testSet(t, gollections.NewHashSetOf[int])
+}
+
+// TestHashSetZeroValue tests that the zero value of a HashSet is ready to use.
+func TestHashSetZeroValue(t *testing.T) {
+	set := &gollections.HashSet[string]{}
+	if set.Contains("a") || !set.IsEmpty() {
+		t.Fatal("expected empty set")
+	}
+	set.Remove("a")
+	set.Add("a")
+	if !set.Contains("a") {
+		t.Fatal("expected set to contain a")
+	}
+}
+
+// TestHashSetIterator tests that iterating over a HashSet visits each element once without copying
+// the set, including when elements are removed during iteration.
+func TestHashSetIterator(t *testing.T) {
+	set := gollections.NewHashSetOf[int]()
+	for i := 0; i < 100; i++ {
+		set.Add(i)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for it := set.Iterator(); it.HasNext(); {
+			it.Next()
+		}
+	})
+	if allocs > 1 {
+		t.Fatalf("expected at most 1 allocation, got %v", allocs)
+	}
+	visited := map[int]int{}
+	for it := set.Iterator(); it.HasNext(); {
+		value, _ := it.Next()
+		visited[value]++
+		if value%3 == 0 {
+			it.Remove()
+		}
+	}
+	if len(visited) != 100 {
+		t.Fatalf("expected 100 elements to be visited, got %d", len(visited))
+	}
+	for value, count := range visited {
+		if count != 1 {
+			t.Fatalf("expected %d to be visited once, got %d", value, count)
+		}
+		if set.Contains(value) == (value%3 == 0) {
+			t.Fatalf("unexpected membership of %d after removal", value)
+		}
+	}
+	if set.Size() != 66 {
+		t.Fatalf("expected size 66, got %d", set.Size())
+	}
+}
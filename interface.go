@@ -1,65 +1,199 @@
 package gollections
 
-// A Collection is a grouping of elements.
-type Collection interface {
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// A CollectionOf is a grouping of elements.
+type CollectionOf[T any] interface {
 	// Add appends new elements to the end of the collection.
-	Add(values ...interface{})
+	Add(values ...T)
+	// All gets a sequence over the elements of the collection for use in range loops.
+	// The sequence panics if the collection is structurally modified during iteration.
+	All() iter.Seq[T]
 	// Clear removes all elements from the collection.
 	Clear()
 	// Contains checks if the collection contains all specified values.
-	Contains(values ...interface{}) bool
+	Contains(values ...T) bool
 	// IsEmpty checks if the collection contains no elements.
 	IsEmpty() bool
+	// Iterator gets an iterator over the elements of the collection.
+	Iterator() Iterator[T]
 	// Remove removes all specified values from the collection.
-	Remove(values ...interface{})
+	Remove(values ...T)
 	// Size gets the number of elements in the collection.
 	Size() int
 	// SliceCopy copies all values in the collection to the supplied slice.
 	SliceCopy(ptrToSlice interface{}) error
 	// ToArray gets an array representation of the collection.
-	ToArray() []interface{}
+	ToArray() []T
 }
 
-// A List is an ordered collection that can be accessed by index.
-type List interface {
-	Collection
+// A ListOf is an ordered collection that can be accessed by index.
+type ListOf[T any] interface {
+	CollectionOf[T]
+	// AddAll appends the elements of the other collection to the end of the list.
+	AddAll(other CollectionOf[T])
+	// Backward gets a sequence over the elements of the list in reverse order.
+	// The sequence panics if the list is structurally modified during iteration.
+	Backward() iter.Seq[T]
 	// IndexOf gets the first occurance of the specified value or -1 if not found.
-	IndexOf(value interface{}) int
+	IndexOf(value T) int
 	// Insert adds elements at the specified index. Can return index not found error.
-	Insert(index int, values ...interface{}) error
+	Insert(index int, values ...T) error
+	// InsertAll adds the elements of the other collection at the specified index. Can return
+	// index not found error.
+	InsertAll(index int, other CollectionOf[T]) error
 	// Get retrieves the value of the element at the specified index.
-	Get(index int) (interface{}, error)
+	Get(index int) (T, error)
+	// ListIterator gets a list iterator with its cursor before the first element of the list.
+	ListIterator() ListIterator[T]
 	// RemoveAt removes the element at the specified index.
 	RemoveAt(index int) error
+	// RemoveIf removes all elements that match the predicate.
+	RemoveIf(predicate func(T) bool)
+	// RemoveRange removes the elements from the first index, inclusive, to the second index,
+	// exclusive. Can return index not found error.
+	RemoveRange(from, to int) error
+	// ReplaceAll overwrites each element with the result of applying the operator to it.
+	ReplaceAll(operator func(T) T)
+	// RetainAll removes all elements that are not in the other collection.
+	RetainAll(other CollectionOf[T])
 	// Set overwrites the value of the element at the specified index.
-	Set(index int, value interface{}) error
+	Set(index int, value T) error
+	// SubList gets a view of the elements from the first index, inclusive, to the second index,
+	// exclusive. Changes to the view are written through to the list. The view is invalidated
+	// by any structural modification of the list that is not made through the view, after which
+	// its methods return or panic with a concurrent modification error.
+	SubList(from, to int) (ListOf[T], error)
 }
 
-// A Queue provides FIFO access to a collection.
-type Queue interface {
-	Collection
+// A QueueOf provides FIFO access to a collection.
+type QueueOf[T any] interface {
+	CollectionOf[T]
 	// PeekFirst gets the value of the first element in the collection.
-	PeekFirst() (interface{}, error)
+	PeekFirst() (T, error)
 	// PopFirst gets the value of the first element in the collection. The element is removed.
-	PopFirst() (interface{}, error)
+	PopFirst() (T, error)
 }
 
-// A Deque is a double ended queue.
-type Deque interface {
-	Queue
+// A DequeOf is a double ended queue.
+type DequeOf[T any] interface {
+	QueueOf[T]
 	// AddFirst adds new elements to the beginning of the collection.
-	AddFirst(values ...interface{})
+	AddFirst(values ...T)
+	// Backward gets a sequence over the elements of the deque from last to first.
+	// The sequence panics if the deque is structurally modified during iteration.
+	Backward() iter.Seq[T]
 	// PeekLast gets the value of the last element in the collection.
-	PeekLast() (interface{}, error)
+	PeekLast() (T, error)
 	// PopLast gets the value of the last element in the collection. The element is removed.
-	PopLast() (interface{}, error)
+	PopLast() (T, error)
+}
+
+// A SetOf is a collection that contains no duplicate elements.
+// Set operations return a new set of the same kind as the receiver, neither operand is modified.
+type SetOf[T any] interface {
+	CollectionOf[T]
+	// Difference gets a set of the elements in this set that are not in the other set.
+	Difference(other SetOf[T]) SetOf[T]
+	// Intersection gets a set of the elements in both this set and the other set.
+	Intersection(other SetOf[T]) SetOf[T]
+	// IsSubsetOf checks if every element in this set is also in the other set.
+	IsSubsetOf(other SetOf[T]) bool
+	// IsSupersetOf checks if every element in the other set is also in this set.
+	IsSupersetOf(other SetOf[T]) bool
+	// SymmetricDifference gets a set of the elements in exactly one of this set and the other set.
+	SymmetricDifference(other SetOf[T]) SetOf[T]
+	// Union gets a set of the elements in either this set or the other set.
+	Union(other SetOf[T]) SetOf[T]
 }
 
-// A Stack provides FILO/LIFO access to a collection.
-type Stack interface {
-	Collection
+// A StackOf provides FILO/LIFO access to a collection.
+type StackOf[T any] interface {
+	CollectionOf[T]
 	// PeekLast gets the value of the last element in the collection.
-	PeekLast() (interface{}, error)
+	PeekLast() (T, error)
 	// PopLast gets the value of the last element in the collection. The element is removed.
-	PopLast() (interface{}, error)
+	PopLast() (T, error)
+}
+
+// A BlockingQueueOf is a queue that may be shared between goroutines and that waits for space to
+// become available when adding an element and for an element to become available when removing
+// one. A closed queue rejects new elements but the elements it holds can still be removed.
+type BlockingQueueOf[T any] interface {
+	QueueOf[T]
+	// Close closes the queue. Goroutines waiting to add an element, or to remove an element from
+	// an empty queue, return a closed error.
+	Close()
+	// DrainTo removes at most max elements, or all elements if max is negative, and adds them to
+	// the supplied collection. Returns the number of elements removed.
+	DrainTo(c CollectionOf[T], max int) int
+	// Offer adds an element to the end of the queue, waiting at most the supplied duration for
+	// space to become available. Returns a timeout error if the queue is still full.
+	Offer(value T, timeout time.Duration) error
+	// Poll removes the first element of the queue, waiting at most the supplied duration for an
+	// element to become available. Returns a timeout error if the queue is still empty.
+	Poll(timeout time.Duration) (T, error)
+	// Put adds an element to the end of the queue, waiting for space to become available.
+	Put(value T) error
+	// PutContext adds an element to the end of the queue, waiting for space to become available
+	// until the context is done.
+	PutContext(ctx context.Context, value T) error
+	// Take removes the first element of the queue, waiting for an element to become available.
+	Take() (T, error)
+	// TakeContext removes the first element of the queue, waiting for an element to become
+	// available until the context is done.
+	TakeContext(ctx context.Context) (T, error)
+}
+
+// A BlockingDequeOf is a blocking queue that can be accessed at both ends.
+type BlockingDequeOf[T any] interface {
+	BlockingQueueOf[T]
+	DequeOf[T]
+	// OfferFirst adds an element to the beginning of the deque, waiting at most the supplied
+	// duration for space to become available. Returns a timeout error if the deque is still full.
+	OfferFirst(value T, timeout time.Duration) error
+	// PollLast removes the last element of the deque, waiting at most the supplied duration for
+	// an element to become available. Returns a timeout error if the deque is still empty.
+	PollLast(timeout time.Duration) (T, error)
+	// PutFirst adds an element to the beginning of the deque, waiting for space to become
+	// available.
+	PutFirst(value T) error
+	// PutFirstContext adds an element to the beginning of the deque, waiting for space to become
+	// available until the context is done.
+	PutFirstContext(ctx context.Context, value T) error
+	// TakeLast removes the last element of the deque, waiting for an element to become
+	// available.
+	TakeLast() (T, error)
+	// TakeLastContext removes the last element of the deque, waiting for an element to become
+	// available until the context is done.
+	TakeLastContext(ctx context.Context) (T, error)
 }
+
+// A Collection is a grouping of untyped elements.
+//
+// Deprecated: Use CollectionOf, which holds elements of a single type.
+type Collection = CollectionOf[interface{}]
+
+// A List is an ordered collection of untyped elements that can be accessed by index.
+//
+// Deprecated: Use ListOf, which holds elements of a single type.
+type List = ListOf[interface{}]
+
+// A Queue provides FIFO access to a collection of untyped elements.
+//
+// Deprecated: Use QueueOf, which holds elements of a single type.
+type Queue = QueueOf[interface{}]
+
+// A Deque is a double ended queue of untyped elements.
+//
+// Deprecated: Use DequeOf, which holds elements of a single type.
+type Deque = DequeOf[interface{}]
+
+// A Stack provides FILO/LIFO access to a collection of untyped elements.
+//
+// Deprecated: Use StackOf, which holds elements of a single type.
+type Stack = StackOf[interface{}]
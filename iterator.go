@@ -0,0 +1,196 @@
+package gollections
+
+import "iter"
+
+// An Iterator traverses the elements of a collection.
+// Iterators fail fast: once the collection is structurally modified by anything other than the
+// iterator itself, further calls return a concurrent modification error.
+type Iterator[T any] interface {
+	// HasNext checks if the iteration has more elements.
+	HasNext() bool
+	// Next gets the next element in the iteration. Returns an error if no such element exists.
+	Next() (T, error)
+	// Remove removes the element last returned by the iterator from the collection.
+	// Returns an illegal state error if no element has been returned since the last call to
+	// Remove or Add.
+	Remove() error
+}
+
+// A ListIterator traverses the elements of a list in either direction and can modify the list at
+// its cursor. The cursor lies between the element that would be returned by Previous and the
+// element that would be returned by Next.
+type ListIterator[T any] interface {
+	Iterator[T]
+	// HasPrevious checks if the iteration has more elements when traversing the list in reverse.
+	HasPrevious() bool
+	// Previous gets the previous element in the iteration. Returns an error if no such element
+	// exists.
+	Previous() (T, error)
+	// NextIndex gets the index of the element that would be returned by Next.
+	NextIndex() int
+	// PreviousIndex gets the index of the element that would be returned by Previous.
+	PreviousIndex() int
+	// Set overwrites the element last returned by Next or Previous.
+	// Returns an illegal state error if no element has been returned since the last call to
+	// Remove or Add.
+	Set(value T) error
+	// Add inserts an element at the cursor. The element is inserted before the element that
+	// would be returned by Next.
+	Add(value T) error
+}
+
+// forward creates a sequence that yields the elements returned by an iterator.
+// The sequence panics if the iterator reports an error, e.g. due to concurrent modification.
+func forward[T any](newIterator func() Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		it := newIterator()
+		for it.HasNext() {
+			value, err := it.Next()
+			if err != nil {
+				panic(err)
+			}
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// backward creates a sequence that yields the elements returned by a list iterator in reverse.
+// The sequence panics if the iterator reports an error, e.g. due to concurrent modification.
+func backward[T any](newIterator func() ListIterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		it := newIterator()
+		for it.HasPrevious() {
+			value, err := it.Previous()
+			if err != nil {
+				panic(err)
+			}
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// indexIterator is a list iterator for lists that support efficient access by index.
+type indexIterator[T any] struct {
+	list     ListOf[T]
+	modCount *int
+	expected int
+	cursor   int
+	last     int
+}
+
+// checkModification verifies that the list has not been modified outside of the iterator.
+func (i *indexIterator[T]) checkModification() error {
+	if *i.modCount != i.expected {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+func (i *indexIterator[T]) HasNext() bool {
+	return i.cursor < i.list.Size()
+}
+
+func (i *indexIterator[T]) Next() (T, error) {
+	var zero T
+	if err := i.checkModification(); err != nil {
+		return zero, err
+	}
+	if !i.HasNext() {
+		return zero, ErrNoSuchElement
+	}
+	value, err := i.list.Get(i.cursor)
+	if err != nil {
+		return zero, err
+	}
+	i.last = i.cursor
+	i.cursor++
+	return value, nil
+}
+
+func (i *indexIterator[T]) Remove() error {
+	if err := i.checkModification(); err != nil {
+		return err
+	}
+	if i.last < 0 {
+		return ErrIllegalState
+	}
+	if err := i.list.RemoveAt(i.last); err != nil {
+		return err
+	}
+	if i.last < i.cursor {
+		i.cursor--
+	}
+	i.last = -1
+	i.expected = *i.modCount
+	return nil
+}
+
+func (i *indexIterator[T]) HasPrevious() bool {
+	return i.cursor > 0
+}
+
+func (i *indexIterator[T]) Previous() (T, error) {
+	var zero T
+	if err := i.checkModification(); err != nil {
+		return zero, err
+	}
+	if !i.HasPrevious() {
+		return zero, ErrNoSuchElement
+	}
+	value, err := i.list.Get(i.cursor - 1)
+	if err != nil {
+		return zero, err
+	}
+	i.cursor--
+	i.last = i.cursor
+	return value, nil
+}
+
+func (i *indexIterator[T]) NextIndex() int {
+	return i.cursor
+}
+
+func (i *indexIterator[T]) PreviousIndex() int {
+	return i.cursor - 1
+}
+
+func (i *indexIterator[T]) Set(value T) error {
+	if err := i.checkModification(); err != nil {
+		return err
+	}
+	if i.last < 0 {
+		return ErrIllegalState
+	}
+	return i.list.Set(i.last, value)
+}
+
+func (i *indexIterator[T]) Add(value T) error {
+	if err := i.checkModification(); err != nil {
+		return err
+	}
+	if i.cursor == i.list.Size() {
+		i.list.Add(value)
+	} else if err := i.list.Insert(i.cursor, value); err != nil {
+		return err
+	}
+	i.cursor++
+	i.last = -1
+	i.expected = *i.modCount
+	return nil
+}
+
+// newIndexIterator initializes a list iterator for the supplied list with the cursor at the
+// specified index. The modCount must be incremented by every structural modification of the list.
+func newIndexIterator[T any](list ListOf[T], modCount *int, index int) *indexIterator[T] {
+	return &indexIterator[T]{
+		list:     list,
+		modCount: modCount,
+		expected: *modCount,
+		cursor:   index,
+		last:     -1,
+	}
+}
@@ -0,0 +1,198 @@
+package gollections
+
+import (
+	"iter"
+	"slices"
+)
+
+// LinkedHashSet is an implementation of a set that remembers the order in which elements were
+// added. Each element is held in a linked list node that is indexed by a map, so adding, removing
+// and checking for an element are O(1).
+// Elements are hashed and compared using the equality strategy of the set.
+// The zero value is an empty set ready to use.
+type LinkedHashSet[T any] struct {
+	nodes    map[uint64][]*listNode[T]
+	list     LinkedList[T]
+	equality Hasher[T]
+}
+
+// equalityStrategy gets the equality strategy of the set.
+func (s *LinkedHashSet[T]) equalityStrategy() Equaler[T] {
+	return s.equality
+}
+
+// emptyCollection initializes an empty set with the same configuration.
+func (s *LinkedHashSet[T]) emptyCollection(bool) CollectionOf[T] {
+	return s.empty()
+}
+
+// hasher gets the equality strategy of the set.
+func (s *LinkedHashSet[T]) hasher() Hasher[T] {
+	if s.equality == nil {
+		return identityEquality[T]{}
+	}
+	return s.equality
+}
+
+// find gets the hash of a value and the node holding an equal element, or nil if the set does not
+// contain the value.
+func (s *LinkedHashSet[T]) find(value T) (uint64, *listNode[T]) {
+	hasher := s.hasher()
+	hash := hasher.Hash(value)
+	for _, node := range s.nodes[hash] {
+		if hasher.Equal(node.value, value) {
+			return hash, node
+		}
+	}
+	return hash, nil
+}
+
+// forget removes a node from the index of the set without unlinking it from the list.
+func (s *LinkedHashSet[T]) forget(node *listNode[T]) {
+	hash := s.hasher().Hash(node.value)
+	bucket := s.nodes[hash]
+	if i := slices.Index(bucket, node); i >= 0 {
+		if len(bucket) == 1 {
+			delete(s.nodes, hash)
+		} else {
+			s.nodes[hash] = slices.Delete(bucket, i, i+1)
+		}
+	}
+}
+
+// empty initializes a new set with the same equality strategy as this set.
+func (s *LinkedHashSet[T]) empty() *LinkedHashSet[T] {
+	return &LinkedHashSet[T]{equality: s.equality}
+}
+
+// Add appends new elements to the end of the collection.
+// Values that are already in the set are ignored and keep their position.
+func (s *LinkedHashSet[T]) Add(values ...T) {
+	if s.nodes == nil {
+		s.nodes = map[uint64][]*listNode[T]{}
+	}
+	for _, value := range values {
+		if hash, node := s.find(value); node == nil {
+			s.nodes[hash] = append(s.nodes[hash], s.list.linkBefore(nil, value))
+		}
+	}
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+// The sequence panics if the collection is structurally modified during iteration.
+func (s *LinkedHashSet[T]) All() iter.Seq[T] {
+	return forward(s.Iterator)
+}
+
+// Clear removes all elements from the collection.
+func (s *LinkedHashSet[T]) Clear() {
+	s.nodes = map[uint64][]*listNode[T]{}
+	s.list.Clear()
+}
+
+// Contains checks if the collection contains all specified values.
+func (s *LinkedHashSet[T]) Contains(values ...T) bool {
+	for _, value := range values {
+		if _, node := s.find(value); node == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (s *LinkedHashSet[T]) IsEmpty() bool {
+	return s.list.IsEmpty()
+}
+
+// Iterator gets an iterator over the elements of the collection in the order they were added.
+func (s *LinkedHashSet[T]) Iterator() Iterator[T] {
+	return &linkedHashSetIterator[T]{
+		linkedListIterator: linkedListIterator[T]{
+			list:     &s.list,
+			next:     s.list.head,
+			expected: s.list.modCount,
+		},
+		set: s,
+	}
+}
+
+// Remove removes all specified values from the collection.
+func (s *LinkedHashSet[T]) Remove(values ...T) {
+	for _, value := range values {
+		if _, node := s.find(value); node != nil {
+			s.forget(node)
+			s.list.unlink(node)
+		}
+	}
+}
+
+// Size gets the number of elements in the collection.
+func (s *LinkedHashSet[T]) Size() int {
+	return s.list.Size()
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (s *LinkedHashSet[T]) SliceCopy(ptrToSlice interface{}) error {
+	return s.list.SliceCopy(ptrToSlice)
+}
+
+// ToArray gets an array representation of the collection.
+func (s *LinkedHashSet[T]) ToArray() []T {
+	return s.list.ToArray()
+}
+
+// Difference gets a set of the elements in this set that are not in the other set.
+func (s *LinkedHashSet[T]) Difference(other SetOf[T]) SetOf[T] {
+	return difference(s.empty(), s, other)
+}
+
+// Intersection gets a set of the elements in both this set and the other set.
+func (s *LinkedHashSet[T]) Intersection(other SetOf[T]) SetOf[T] {
+	return intersection(s.empty(), s, other)
+}
+
+// IsSubsetOf checks if every element in this set is also in the other set.
+func (s *LinkedHashSet[T]) IsSubsetOf(other SetOf[T]) bool {
+	return isSubset[T](s, other)
+}
+
+// IsSupersetOf checks if every element in the other set is also in this set.
+func (s *LinkedHashSet[T]) IsSupersetOf(other SetOf[T]) bool {
+	return isSubset(other, SetOf[T](s))
+}
+
+// SymmetricDifference gets a set of the elements in exactly one of this set and the other set.
+func (s *LinkedHashSet[T]) SymmetricDifference(other SetOf[T]) SetOf[T] {
+	return symmetricDifference(s.empty(), s, other)
+}
+
+// Union gets a set of the elements in either this set or the other set.
+func (s *LinkedHashSet[T]) Union(other SetOf[T]) SetOf[T] {
+	return union(s.empty(), s, other)
+}
+
+// linkedHashSetIterator iterates over the nodes of a linked hash set, keeping the node index in
+// sync when elements are removed.
+type linkedHashSetIterator[T any] struct {
+	linkedListIterator[T]
+	set *LinkedHashSet[T]
+}
+
+func (i *linkedHashSetIterator[T]) Remove() error {
+	last := i.last
+	if err := i.linkedListIterator.Remove(); err != nil {
+		return err
+	}
+	i.set.forget(last)
+	return nil
+}
+
+// NewLinkedHashSetOf initializes a set that iterates over its elements in the order they were
+// added.
+func NewLinkedHashSetOf[T any](options ...Option[T]) SetOf[T] {
+	return &LinkedHashSet[T]{
+		nodes:    map[uint64][]*listNode[T]{},
+		equality: hasherOrDefault(newOptions(options).equality),
+	}
+}
@@ -0,0 +1,44 @@
+package gollections_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the LinkedHashSet as an implementation of Collection.
+func TestLinkedHashSetCollection(t *testing.T) {
+	testCollection(t, gollections.NewLinkedHashSetOf[any]())
+}
+
+// Test the LinkedHashSet as an implementation of Set.
+func TestLinkedHashSet(t *testing.T) {
+	testSet(t, gollections.NewLinkedHashSetOf[int])
+}
+
+// TestLinkedHashSetOrder tests that a LinkedHashSet keeps its elements in insertion order.
+func TestLinkedHashSetOrder(t *testing.T) {
+	set := &gollections.LinkedHashSet[string]{}
+	set.Add("c", "a", "b", "a")
+	set.Remove("a")
+	set.Add("a", "c")
+	expected := []string{"c", "b", "a"}
+	if got := set.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	other := gollections.NewLinkedHashSetOf[string]()
+	other.Add("d", "b")
+	expected = []string{"c", "b", "a", "d"}
+	if got := set.Union(other).ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	got := []string{}
+	for value := range set.All() {
+		got = append(got, value)
+	}
+	expected = []string{"c", "b", "a"}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
@@ -1,20 +1,17 @@
 package gollections
 
-import (
-	"fmt"
-	"reflect"
-)
+import "iter"
 
 // listNode represents a single element in a doubly linked list.
-type listNode struct {
-	value    interface{}
-	previous *listNode
-	next     *listNode
+type listNode[T any] struct {
+	value    T
+	previous *listNode[T]
+	next     *listNode[T]
 }
 
 // addBefore inserts an element to the left of this node.
-func (n *listNode) addBefore(value interface{}) *listNode {
-	e := &listNode{value: value}
+func (n *listNode[T]) addBefore(value T) *listNode[T] {
+	e := &listNode[T]{value: value}
 	e.next = n
 	if n.previous != nil {
 		e.previous = n.previous
@@ -25,8 +22,8 @@ func (n *listNode) addBefore(value interface{}) *listNode {
 }
 
 // addAfter inserts and element to the right of this node.
-func (n *listNode) addAfter(value interface{}) *listNode {
-	e := &listNode{value: value}
+func (n *listNode[T]) addAfter(value T) *listNode[T] {
+	e := &listNode[T]{value: value}
 	e.previous = n
 	if n.next != nil {
 		e.next = n.next
@@ -38,7 +35,7 @@ func (n *listNode) addAfter(value interface{}) *listNode {
 
 // remove removes this element from its neighbors.
 // The neighboring elements are linked if they exist.
-func (n *listNode) remove() {
+func (n *listNode[T]) remove() {
 	if n.previous != nil {
 		n.previous.next = n.next
 	}
@@ -49,126 +46,159 @@ func (n *listNode) remove() {
 	n.next = nil
 }
 
-// linkedList is an implementation of a doubly linked list.
-type linkedList struct {
-	head   *listNode
-	tail   *listNode
-	length int
+// LinkedList is an implementation of a doubly linked list.
+// Access by index walks from whichever of the head, the tail or the most recently accessed element
+// is closest, so accessing elements in order by index is amortized O(1). As a result, reading an
+// element by index modifies the list and must not be done concurrently with any other access,
+// which is why a SyncList takes the write lock for Get.
+// The zero value is an empty list ready to use.
+type LinkedList[T any] struct {
+	head        *listNode[T]
+	tail        *listNode[T]
+	length      int
+	cursor      *listNode[T]
+	cursorIndex int
+	equality    Equaler[T]
+	modCount    int
+}
+
+// equalityStrategy gets the equality strategy of the list.
+func (l *LinkedList[T]) equalityStrategy() Equaler[T] {
+	return l.equality
+}
+
+// emptyCollection initializes an empty list with the same configuration.
+func (l *LinkedList[T]) emptyCollection(bool) CollectionOf[T] {
+	return &LinkedList[T]{equality: l.equality}
 }
 
 // nodeAt retrieves the element at the specified index.
-func (l *linkedList) nodeAt(index int) (*listNode, error) {
+func (l *LinkedList[T]) nodeAt(index int) (*listNode[T], error) {
 	if index < 0 || index >= l.length {
 		return nil, ErrIndexOutOfBounds
 	}
-	current := l.head
-	for index > 0 {
+	current, from := l.head, 0
+	if back := l.length - 1 - index; back < index {
+		current, from = l.tail, l.length-1
+	}
+	if l.cursor != nil && abs(index-l.cursorIndex) < abs(index-from) {
+		current, from = l.cursor, l.cursorIndex
+	}
+	for ; from < index; from++ {
 		current = current.next
-		index--
 	}
+	for ; from > index; from-- {
+		current = current.previous
+	}
+	l.cursor, l.cursorIndex = current, index
 	return current, nil
 }
 
-// Add appends new elements to the end of the collection.
-func (l *linkedList) Add(values ...interface{}) {
-	for _, value := range values {
-		if l.length == 0 {
-			e := &listNode{value: value}
+// abs gets the absolute value of an integer.
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// linkBefore inserts a new element to the left of the supplied node.
+// If the node is nil the element is appended to the end of the list.
+func (l *LinkedList[T]) linkBefore(n *listNode[T], value T) *listNode[T] {
+	switch {
+	case l.cursor == nil || n == nil:
+		// appending does not move any element
+	case n == l.head:
+		l.cursorIndex++
+	default:
+		l.cursor = nil
+	}
+	var e *listNode[T]
+	switch {
+	case l.length == 0:
+		e = &listNode[T]{value: value}
+		l.head = e
+		l.tail = e
+	case n == nil:
+		e = l.tail.addAfter(value)
+		l.tail = e
+	default:
+		e = n.addBefore(value)
+		if n == l.head {
 			l.head = e
-			l.tail = e
-		} else {
-			l.tail.addAfter(value)
-			l.tail = l.tail.next
 		}
-		l.length++
+	}
+	l.length++
+	l.modCount++
+	return e
+}
+
+// unlink removes the supplied node from the list.
+func (l *LinkedList[T]) unlink(n *listNode[T]) {
+	switch {
+	case l.cursor == nil:
+	case n == l.cursor:
+		l.cursor = nil
+	case n == l.head:
+		l.cursorIndex--
+	case n != l.tail:
+		l.cursor = nil
+	}
+	if n == l.head {
+		l.head = n.next
+	}
+	if n == l.tail {
+		l.tail = n.previous
+	}
+	n.remove()
+	l.length--
+	l.modCount++
+}
+
+// Add appends new elements to the end of the collection.
+func (l *LinkedList[T]) Add(values ...T) {
+	for _, value := range values {
+		l.linkBefore(nil, value)
 	}
 }
 
 // Clear removes all elements from the collection.
-func (l *linkedList) Clear() {
+func (l *LinkedList[T]) Clear() {
 	l.head = nil
 	l.tail = nil
 	l.length = 0
+	l.cursor = nil
+	l.modCount++
 }
 
 // Contains checks if the collection contains all specified values.
-func (l *linkedList) Contains(values ...interface{}) bool {
-	seen := map[interface{}]bool{}
-	current := l.head
-	for current != nil {
-		seen[current.value] = true
-		current = current.next
-	}
-	for _, value := range values {
-		if _, ok := seen[value]; !ok {
-			return false
-		}
-	}
-	return true
+func (l *LinkedList[T]) Contains(values ...T) bool {
+	return containsAll(l.equality, l.ToArray(), values)
 }
 
 // IsEmpty checks if the collection contains no elements.
-func (l *linkedList) IsEmpty() bool {
+func (l *LinkedList[T]) IsEmpty() bool {
 	return l.length == 0
 }
 
 // Remove removes all specified values from the collection.
-func (l *linkedList) Remove(values ...interface{}) {
-	seen := map[interface{}]bool{}
-	for _, value := range values {
-		seen[value] = true
-	}
-	current := l.head
-	for current != nil {
-		e := current
-		current = current.next
-		if _, ok := seen[e.value]; ok {
-			if e == l.head {
-				l.head = l.head.next
-			}
-			if e == l.tail {
-				l.tail = l.tail.previous
-			}
-			e.remove()
-			l.length--
-		}
-	}
+func (l *LinkedList[T]) Remove(values ...T) {
+	l.RemoveIf(matcher(l.equality, values))
 }
 
 // Size gets the number of elements in the collection.
-func (l *linkedList) Size() int {
+func (l *LinkedList[T]) Size() int {
 	return l.length
 }
 
 // SliceCopy copies all values in the collection to the supplied slice.
-func (l *linkedList) SliceCopy(ptrToSlice interface{}) error {
-	value := reflect.ValueOf(ptrToSlice)
-	if value.Kind() != reflect.Ptr {
-		return fmt.Errorf("supplied value of type %v is not a pointer", value.Type())
-	}
-	value = value.Elem()
-	if value.Kind() != reflect.Slice {
-		return fmt.Errorf("supplied value of type %v is not a pointer to a slice", value.Type())
-	}
-	value.Set(reflect.MakeSlice(value.Type(), l.length, l.length))
-	current := l.head
-	index := 0
-	for current != nil {
-		listValue := reflect.ValueOf(current.value)
-		if value.Index(index).Kind() != listValue.Kind() {
-			return fmt.Errorf("cannot assign type %v to element of type %v", listValue.Kind(),
-				value.Index(index).Kind())
-		}
-		value.Index(index).Set(reflect.ValueOf(current.value))
-		current = current.next
-		index++
-	}
-	return nil
+func (l *LinkedList[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, l.ToArray())
 }
 
 // ToArray gets an array representation of the collection.
-func (l *linkedList) ToArray() []interface{} {
-	array := make([]interface{}, l.length)
+func (l *LinkedList[T]) ToArray() []T {
+	array := make([]T, l.length)
 	current := l.head
 	index := 0
 	for current != nil {
@@ -180,11 +210,12 @@ func (l *linkedList) ToArray() []interface{} {
 }
 
 // IndexOf gets the first occurance of the specified value or -1 if not found.
-func (l *linkedList) IndexOf(value interface{}) int {
+func (l *LinkedList[T]) IndexOf(value T) int {
+	equality := equalityOrDefault(l.equality)
 	current := l.head
 	index := 0
 	for current != nil {
-		if reflect.DeepEqual(value, current.value) {
+		if equality.Equal(value, current.value) {
 			return index
 		}
 		index++
@@ -194,7 +225,7 @@ func (l *linkedList) IndexOf(value interface{}) int {
 }
 
 // Insert adds elements at the specified index. Can return index not found error.
-func (l *linkedList) Insert(index int, values ...interface{}) error {
+func (l *LinkedList[T]) Insert(index int, values ...T) error {
 	if len(values) == 0 {
 		return nil
 	}
@@ -202,46 +233,39 @@ func (l *linkedList) Insert(index int, values ...interface{}) error {
 	if err != nil {
 		return err
 	}
-	current = current.addBefore(values[0])
-	if current.next == l.head {
-		l.head = current
-	}
-	l.length++
-	for i := 1; i < len(values); i++ {
-		current = current.addAfter(values[i])
-		l.length++
+	for _, value := range values {
+		l.linkBefore(current, value)
 	}
+	l.cursor, l.cursorIndex = current, index+len(values)
 	return nil
 }
 
 // Get retrieves the value of the element at the specified index.
-func (l *linkedList) Get(index int) (interface{}, error) {
+func (l *LinkedList[T]) Get(index int) (T, error) {
 	e, err := l.nodeAt(index)
 	if err != nil {
-		return nil, err
+		var zero T
+		return zero, err
 	}
 	return e.value, nil
 }
 
 // RemoveAt removes the element at the specified index.
-func (l *linkedList) RemoveAt(index int) error {
+func (l *LinkedList[T]) RemoveAt(index int) error {
 	current, err := l.nodeAt(index)
 	if err != nil {
 		return err
 	}
-	if current == l.head {
-		l.head = l.head.next
-	}
-	if current == l.tail {
-		l.tail = l.tail.previous
+	next := current.next
+	l.unlink(current)
+	if next != nil {
+		l.cursor, l.cursorIndex = next, index
 	}
-	current.remove()
-	l.length--
 	return nil
 }
 
 // Set overwrites the value of the element at the specified index.
-func (l *linkedList) Set(index int, value interface{}) error {
+func (l *LinkedList[T]) Set(index int, value T) error {
 	current, err := l.nodeAt(index)
 	if err != nil {
 		return err
@@ -250,81 +274,308 @@ func (l *linkedList) Set(index int, value interface{}) error {
 	return nil
 }
 
+// AddAll appends the elements of the other collection to the end of the list.
+func (l *LinkedList[T]) AddAll(other CollectionOf[T]) {
+	l.Add(other.ToArray()...)
+}
+
+// InsertAll adds the elements of the other collection at the specified index. Can return index
+// not found error.
+func (l *LinkedList[T]) InsertAll(index int, other CollectionOf[T]) error {
+	return l.Insert(index, other.ToArray()...)
+}
+
+// RemoveIf removes all elements that match the predicate in a single pass.
+func (l *LinkedList[T]) RemoveIf(predicate func(T) bool) {
+	current := l.head
+	for current != nil {
+		e := current
+		current = current.next
+		if predicate(e.value) {
+			l.unlink(e)
+		}
+	}
+}
+
+// RemoveRange removes the elements from the first index, inclusive, to the second index,
+// exclusive. Can return index not found error.
+func (l *LinkedList[T]) RemoveRange(from, to int) error {
+	if from < 0 || to > l.length || from > to {
+		return ErrIndexOutOfBounds
+	}
+	if from == to {
+		return nil
+	}
+	current, err := l.nodeAt(from)
+	if err != nil {
+		return err
+	}
+	for i := from; i < to; i++ {
+		e := current
+		current = current.next
+		l.unlink(e)
+	}
+	return nil
+}
+
+// ReplaceAll overwrites each element with the result of applying the operator to it.
+func (l *LinkedList[T]) ReplaceAll(operator func(T) T) {
+	for current := l.head; current != nil; current = current.next {
+		current.value = operator(current.value)
+	}
+}
+
+// RetainAll removes all elements that are not in the other collection in a single pass.
+func (l *LinkedList[T]) RetainAll(other CollectionOf[T]) {
+	contains := membership(l.equality, other)
+	l.RemoveIf(func(value T) bool {
+		return !contains(value)
+	})
+}
+
+// SubList gets a view of the elements from the first index, inclusive, to the second index,
+// exclusive.
+func (l *LinkedList[T]) SubList(from, to int) (ListOf[T], error) {
+	return newSubList[T](l, &l.modCount, nil, from, to)
+}
+
 // PeekFirst gets the value of the first element in the collection.
-func (l *linkedList) PeekFirst() (interface{}, error) {
+func (l *LinkedList[T]) PeekFirst() (T, error) {
 	if l.head == nil {
-		return nil, ErrNoSuchElement
+		var zero T
+		return zero, ErrNoSuchElement
 	}
 	return l.head.value, nil
 }
 
 // PopFirst gets the value of the first element in the collection. The element is removed.
-func (l *linkedList) PopFirst() (interface{}, error) {
+func (l *LinkedList[T]) PopFirst() (T, error) {
 	if l.head == nil {
-		return nil, ErrNoSuchElement
+		var zero T
+		return zero, ErrNoSuchElement
 	}
 	temp := l.head
-	l.head = l.head.next
-	temp.remove()
-	l.length--
+	l.unlink(temp)
 	return temp.value, nil
 }
 
 // PeekLast gets the value of the last element in the collection.
-func (l *linkedList) PeekLast() (interface{}, error) {
+func (l *LinkedList[T]) PeekLast() (T, error) {
 	if l.tail == nil {
-		return nil, ErrNoSuchElement
+		var zero T
+		return zero, ErrNoSuchElement
 	}
 	return l.tail.value, nil
 }
 
 // PopLast gets the value of the last element in the collection. The element is removed.
-func (l *linkedList) PopLast() (interface{}, error) {
+func (l *LinkedList[T]) PopLast() (T, error) {
 	if l.tail == nil {
-		return nil, ErrNoSuchElement
+		var zero T
+		return zero, ErrNoSuchElement
 	}
 	temp := l.tail
-	l.tail = l.tail.previous
-	temp.remove()
-	l.length--
+	l.unlink(temp)
 	return temp.value, nil
 }
 
 // AddFirst adds new elements to the beginning of the collection.
-func (l *linkedList) AddFirst(values ...interface{}) {
-	if len(values) == 0 {
-		return
+func (l *LinkedList[T]) AddFirst(values ...T) {
+	for _, value := range values {
+		l.linkBefore(l.head, value)
 	}
-	for value := range values {
-		if l.length == 0 {
-			l.Add(value)
-			continue
-		}
-		l.Insert(0, value)
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+// The sequence panics if the collection is structurally modified during iteration.
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return forward(l.Iterator)
+}
+
+// Backward gets a sequence over the elements of the list in reverse order.
+// The sequence panics if the list is structurally modified during iteration.
+func (l *LinkedList[T]) Backward() iter.Seq[T] {
+	return backward(func() ListIterator[T] {
+		return &linkedListIterator[T]{list: l, index: l.length, expected: l.modCount}
+	})
+}
+
+// Iterator gets an iterator over the elements of the collection.
+func (l *LinkedList[T]) Iterator() Iterator[T] {
+	return l.ListIterator()
+}
+
+// ListIterator gets a list iterator with its cursor before the first element of the list.
+func (l *LinkedList[T]) ListIterator() ListIterator[T] {
+	return &linkedListIterator[T]{list: l, next: l.head, expected: l.modCount}
+}
+
+// linkedListIterator is a list iterator that follows the links between list nodes.
+type linkedListIterator[T any] struct {
+	list     *LinkedList[T]
+	next     *listNode[T]
+	last     *listNode[T]
+	index    int
+	expected int
+}
+
+// checkModification verifies that the list has not been modified outside of the iterator.
+func (i *linkedListIterator[T]) checkModification() error {
+	if i.list.modCount != i.expected {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+func (i *linkedListIterator[T]) HasNext() bool {
+	return i.index < i.list.length
+}
+
+func (i *linkedListIterator[T]) Next() (T, error) {
+	var zero T
+	if err := i.checkModification(); err != nil {
+		return zero, err
+	}
+	if !i.HasNext() {
+		return zero, ErrNoSuchElement
+	}
+	i.last = i.next
+	i.next = i.next.next
+	i.index++
+	return i.last.value, nil
+}
+
+func (i *linkedListIterator[T]) Remove() error {
+	if err := i.checkModification(); err != nil {
+		return err
+	}
+	if i.last == nil {
+		return ErrIllegalState
+	}
+	if i.last == i.next {
+		i.next = i.next.next
+	} else {
+		i.index--
+	}
+	i.list.unlink(i.last)
+	i.last = nil
+	i.expected = i.list.modCount
+	return nil
+}
+
+func (i *linkedListIterator[T]) HasPrevious() bool {
+	return i.index > 0
+}
+
+func (i *linkedListIterator[T]) Previous() (T, error) {
+	var zero T
+	if err := i.checkModification(); err != nil {
+		return zero, err
+	}
+	if !i.HasPrevious() {
+		return zero, ErrNoSuchElement
+	}
+	if i.next == nil {
+		i.next = i.list.tail
+	} else {
+		i.next = i.next.previous
+	}
+	i.last = i.next
+	i.index--
+	return i.last.value, nil
+}
+
+func (i *linkedListIterator[T]) NextIndex() int {
+	return i.index
+}
+
+func (i *linkedListIterator[T]) PreviousIndex() int {
+	return i.index - 1
+}
+
+func (i *linkedListIterator[T]) Set(value T) error {
+	if err := i.checkModification(); err != nil {
+		return err
 	}
+	if i.last == nil {
+		return ErrIllegalState
+	}
+	i.last.value = value
+	return nil
+}
+
+func (i *linkedListIterator[T]) Add(value T) error {
+	if err := i.checkModification(); err != nil {
+		return err
+	}
+	i.list.linkBefore(i.next, value)
+	i.index++
+	i.last = nil
+	i.expected = i.list.modCount
+	return nil
+}
+
+// newLinkedList initializes a linked list with the supplied options.
+func newLinkedList[T any](opts []Option[T]) *LinkedList[T] {
+	return &LinkedList[T]{equality: newOptions(opts).equality}
+}
+
+// NewLinkedCollectionOf initializes a collection backed by a linked list.
+func NewLinkedCollectionOf[T any](options ...Option[T]) CollectionOf[T] {
+	return newLinkedList(options)
+}
+
+// NewLinkedListOf initializes a list backed by a linked list.
+func NewLinkedListOf[T any](options ...Option[T]) ListOf[T] {
+	return newLinkedList(options)
+}
+
+// NewLinkedQueueOf initializes a queue backed by a linked list.
+func NewLinkedQueueOf[T any](options ...Option[T]) QueueOf[T] {
+	return newLinkedList(options)
+}
+
+// NewLinkedStackOf initializes a stack backed by a linked list.
+func NewLinkedStackOf[T any](options ...Option[T]) StackOf[T] {
+	return newLinkedList(options)
+}
+
+// NewLinkedDequeOf initializes a deque backed by a linked list.
+func NewLinkedDequeOf[T any](options ...Option[T]) DequeOf[T] {
+	return newLinkedList(options)
 }
 
-// NewLinkedCollection initializes a collection backed by a linked list.
+// NewLinkedCollection initializes a collection of untyped elements backed by a linked list.
+//
+// Deprecated: Use NewLinkedCollectionOf, which holds elements of a single type.
 func NewLinkedCollection() Collection {
-	return &linkedList{}
+	return NewLinkedCollectionOf[interface{}]()
 }
 
-// NewLinkedList initializes a list backed by a linked list.
+// NewLinkedList initializes a list of untyped elements backed by a linked list.
+//
+// Deprecated: Use NewLinkedListOf, which holds elements of a single type.
 func NewLinkedList() List {
-	return &linkedList{}
+	return NewLinkedListOf[interface{}]()
 }
 
-// NewLinkedQueue initializes a queue backed by a linked list.
+// NewLinkedQueue initializes a queue of untyped elements backed by a linked list.
+//
+// Deprecated: Use NewLinkedQueueOf, which holds elements of a single type.
 func NewLinkedQueue() Queue {
-	return &linkedList{}
+	return NewLinkedQueueOf[interface{}]()
 }
 
-// NewLinkedStack initializes a stack backed by a linked list.
+// NewLinkedStack initializes a stack of untyped elements backed by a linked list.
+//
+// Deprecated: Use NewLinkedStackOf, which holds elements of a single type.
 func NewLinkedStack() Stack {
-	return &linkedList{}
+	return NewLinkedStackOf[interface{}]()
 }
 
-// NewLinkedDeque initializes a deque backed by a linked list.
+// NewLinkedDeque initializes a deque of untyped elements backed by a linked list.
+//
+// Deprecated: Use NewLinkedDequeOf, which holds elements of a single type.
 func NewLinkedDeque() Deque {
-	return &linkedList{}
+	return NewLinkedDequeOf[interface{}]()
 }
@@ -1,6 +1,8 @@
 package gollections_test
 
 import (
+	"fmt"
+	"math/rand/v2"
 	"reflect"
 	"testing"
 
@@ -191,3 +193,182 @@ func TestLinkedDeque(t *testing.T) {
 		t.Fatal("expected deque to be empty")
 	}
 }
+
+// Test the linkedList as an implementation of Stack.
+func TestLinkedStack(t *testing.T) {
+	testStack(t, gollections.NewLinkedStackOf[any]())
+}
+
+// TestLinkedListTyped tests the linkedList with a concrete element type.
+func TestLinkedListTyped(t *testing.T) {
+	testListTyped(t, gollections.NewLinkedListOf[string]())
+}
+
+// TestLinkedDequeEmptied tests that a deque emptied from either end holds no stale elements.
+func TestLinkedDequeEmptied(t *testing.T) {
+	testDequeEmptied(t, gollections.NewLinkedDequeOf[int]())
+}
+
+// TestLinkedListIterator tests the iterators of the linkedList.
+func TestLinkedListIterator(t *testing.T) {
+	testListIterator(t, gollections.NewLinkedListOf[int]())
+}
+
+// TestLinkedListBulk tests the bulk operations of the LinkedList.
+func TestLinkedListBulk(t *testing.T) {
+	testListBulk(t, gollections.NewLinkedListOf[int]())
+}
+
+// TestLinkedListSubList tests sub list views of the LinkedList.
+func TestLinkedListSubList(t *testing.T) {
+	testSubList(t, gollections.NewLinkedListOf[int]())
+}
+
+// TestLinkedListIndexedAccess tests that access by index stays correct while the list is modified,
+// by comparing a linked list to an array list after random operations.
+func TestLinkedListIndexedAccess(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	list := gollections.NewLinkedListOf[int]()
+	model := gollections.NewArrayListOf[int]()
+	for step := 0; step < 5000; step++ {
+		size := model.Size()
+		index := 0
+		if size > 0 {
+			index = r.IntN(size)
+		}
+		switch op := r.IntN(9); {
+		case op == 0 || size == 0:
+			list.Add(step)
+			model.Add(step)
+		case op == 1:
+			list.(gollections.DequeOf[int]).AddFirst(step)
+			model.(gollections.DequeOf[int]).AddFirst(step)
+		case op == 2:
+			list.Insert(index, step, -step)
+			model.Insert(index, step, -step)
+		case op == 3:
+			list.RemoveAt(index)
+			model.RemoveAt(index)
+		case op == 4:
+			list.(gollections.DequeOf[int]).PopFirst()
+			model.(gollections.DequeOf[int]).PopFirst()
+		case op == 5:
+			list.(gollections.DequeOf[int]).PopLast()
+			model.(gollections.DequeOf[int]).PopLast()
+		case op == 6:
+			list.Set(index, step)
+			model.Set(index, step)
+		default:
+			got, err := list.Get(index)
+			expected, _ := model.Get(index)
+			if err != nil || got != expected {
+				t.Fatalf("step %d: expected %d at index %d, got %d, err: %v", step, expected, index, got, err)
+			}
+		}
+		if step%100 == 0 && !reflect.DeepEqual(model.ToArray(), list.ToArray()) {
+			t.Fatalf("step %d: expected %v, got %v", step, model.ToArray(), list.ToArray())
+		}
+	}
+}
+
+// benchmarkSizes are the list sizes used by the indexed access benchmarks.
+var benchmarkSizes = []int{100, 1000, 10000}
+
+// newBenchmarkList initializes a linked list holding the supplied number of elements.
+func newBenchmarkList(size int) gollections.ListOf[int] {
+	list := gollections.NewLinkedListOf[int]()
+	for i := 0; i < size; i++ {
+		list.Add(i)
+	}
+	return list
+}
+
+// BenchmarkLinkedListGetSequential measures a loop over all indexes of a list using Get.
+func BenchmarkLinkedListGetSequential(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			list := newBenchmarkList(size)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				for i := 0; i < list.Size(); i++ {
+					list.Get(i)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLinkedListGetReverse measures a loop over all indexes of a list in reverse using Get.
+func BenchmarkLinkedListGetReverse(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			list := newBenchmarkList(size)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				for i := list.Size() - 1; i >= 0; i-- {
+					list.Get(i)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLinkedListGetLast measures getting the last element of a list by index.
+func BenchmarkLinkedListGetLast(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			list := newBenchmarkList(size)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				list.Get(size - 1)
+			}
+		})
+	}
+}
+
+// BenchmarkLinkedListSetSequential measures a loop over all indexes of a list using Set.
+func BenchmarkLinkedListSetSequential(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			list := newBenchmarkList(size)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				for i := 0; i < list.Size(); i++ {
+					list.Set(i, n)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLinkedListInsertSequential measures inserting an element after each element of a list.
+func BenchmarkLinkedListInsertSequential(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				list := newBenchmarkList(size)
+				b.StartTimer()
+				for i := 1; i < list.Size(); i += 2 {
+					list.Insert(i, -1)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLinkedListRemoveAtSequential measures removing every other element of a list by index.
+func BenchmarkLinkedListRemoveAtSequential(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				list := newBenchmarkList(size)
+				b.StartTimer()
+				for i := 0; i < list.Size(); i++ {
+					list.RemoveAt(i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package gollections
+
+// An Option configures a collection when it is initialized.
+type Option[T any] func(*options[T])
+
+// options holds the configuration of a collection.
+type options[T any] struct {
+	equality Equaler[T]
+}
+
+// WithEquality sets the strategy a collection uses to decide whether two elements are equal,
+// e.g. in Contains, Remove and IndexOf. Strategies that are also a Hasher let hash based
+// collections and bulk operations avoid comparing every pair of elements.
+// The default strategy is IdentityEquality.
+func WithEquality[T any](equality Equaler[T]) Option[T] {
+	return func(o *options[T]) {
+		o.equality = equality
+	}
+}
+
+// newOptions applies the supplied options to the default configuration.
+func newOptions[T any](opts []Option[T]) options[T] {
+	o := options[T]{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,330 @@
+package gollections
+
+import "iter"
+
+// A Handle refers to an element of a PriorityQueue so that its priority can be changed after it
+// has been added.
+type Handle[T any] struct {
+	value T
+	index int
+	queue *PriorityQueue[T]
+}
+
+// Value gets the element referred to by the handle.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// PriorityQueue is an implementation of a queue backed by a binary heap.
+// PeekFirst and PopFirst get the smallest element according to the comparator of the queue, use
+// a reversed comparator to get the largest element instead. Adding and removing elements are
+// O(log n). Elements with equal priority are not kept in any particular order.
+type PriorityQueue[T any] struct {
+	compare  Comparator[T]
+	heap     []*Handle[T]
+	equality Equaler[T]
+	modCount int
+}
+
+// equalityStrategy gets the equality strategy of the queue.
+func (q *PriorityQueue[T]) equalityStrategy() Equaler[T] {
+	return q.equality
+}
+
+// emptyCollection initializes an empty queue with the same configuration.
+func (q *PriorityQueue[T]) emptyCollection(bool) CollectionOf[T] {
+	return &PriorityQueue[T]{compare: q.compare, equality: q.equality}
+}
+
+// less compares the elements at the specified positions of the heap.
+func (q *PriorityQueue[T]) less(i, j int) bool {
+	return q.compare(q.heap[i].value, q.heap[j].value) < 0
+}
+
+// swap exchanges the elements at the specified positions of the heap.
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+	q.heap[i].index = i
+	q.heap[j].index = j
+}
+
+// up moves the element at the specified position toward the root until the heap is ordered.
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(i, parent) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at the specified position toward the leaves until the heap is ordered.
+// Returns true if the element was moved.
+func (q *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(q.heap)
+	for {
+		smallest := 2*i + 1
+		if smallest >= n {
+			break
+		}
+		if right := smallest + 1; right < n && q.less(right, smallest) {
+			smallest = right
+		}
+		if !q.less(smallest, i) {
+			break
+		}
+		q.swap(i, smallest)
+		i = smallest
+	}
+	return i > start
+}
+
+// heapify orders the entire heap in O(n).
+func (q *PriorityQueue[T]) heapify() {
+	for i, h := range q.heap {
+		h.index = i
+		h.queue = q
+	}
+	for i := len(q.heap)/2 - 1; i >= 0; i-- {
+		q.down(i)
+	}
+}
+
+// removeAt removes the element at the specified position of the heap.
+func (q *PriorityQueue[T]) removeAt(i int) *Handle[T] {
+	last := len(q.heap) - 1
+	if i != last {
+		q.swap(i, last)
+	}
+	h := q.heap[last]
+	q.heap[last] = nil
+	q.heap = q.heap[:last]
+	if i != last && !q.down(i) {
+		q.up(i)
+	}
+	h.index = -1
+	h.queue = nil
+	q.modCount++
+	return h
+}
+
+// owns checks if the supplied handle refers to an element of this queue.
+func (q *PriorityQueue[T]) owns(h *Handle[T]) bool {
+	return h != nil && h.queue == q && h.index >= 0
+}
+
+// Add appends new elements to the end of the collection.
+func (q *PriorityQueue[T]) Add(values ...T) {
+	for _, value := range values {
+		q.Offer(value)
+	}
+}
+
+// All gets a sequence over the elements of the collection for use in range loops.
+// Elements are visited in heap order rather than priority order.
+// The sequence panics if the collection is structurally modified during iteration.
+func (q *PriorityQueue[T]) All() iter.Seq[T] {
+	return forward(q.Iterator)
+}
+
+// Clear removes all elements from the collection.
+func (q *PriorityQueue[T]) Clear() {
+	for _, h := range q.heap {
+		h.index = -1
+		h.queue = nil
+	}
+	q.heap = nil
+	q.modCount++
+}
+
+// Contains checks if the collection contains all specified values.
+func (q *PriorityQueue[T]) Contains(values ...T) bool {
+	return containsAll(q.equality, q.ToArray(), values)
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (q *PriorityQueue[T]) IsEmpty() bool {
+	return len(q.heap) == 0
+}
+
+// Iterator gets an iterator over the elements of the collection in heap order.
+func (q *PriorityQueue[T]) Iterator() Iterator[T] {
+	handles := make([]*Handle[T], len(q.heap))
+	copy(handles, q.heap)
+	return &priorityQueueIterator[T]{queue: q, handles: handles, expected: q.modCount}
+}
+
+// Remove removes all specified values from the collection.
+func (q *PriorityQueue[T]) Remove(values ...T) {
+	match := matcher(q.equality, values)
+	kept := q.heap[:0]
+	for _, h := range q.heap {
+		if match(h.value) {
+			h.index = -1
+			h.queue = nil
+			continue
+		}
+		kept = append(kept, h)
+	}
+	if len(kept) == len(q.heap) {
+		return
+	}
+	clear(q.heap[len(kept):])
+	q.heap = kept
+	q.heapify()
+	q.modCount++
+}
+
+// Size gets the number of elements in the collection.
+func (q *PriorityQueue[T]) Size() int {
+	return len(q.heap)
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (q *PriorityQueue[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, q.ToArray())
+}
+
+// ToArray gets an array representation of the collection in heap order.
+func (q *PriorityQueue[T]) ToArray() []T {
+	array := make([]T, len(q.heap))
+	for i, h := range q.heap {
+		array[i] = h.value
+	}
+	return array
+}
+
+// PeekFirst gets the value of the first element in the collection.
+func (q *PriorityQueue[T]) PeekFirst() (T, error) {
+	if len(q.heap) == 0 {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	return q.heap[0].value, nil
+}
+
+// PopFirst gets the value of the first element in the collection. The element is removed.
+func (q *PriorityQueue[T]) PopFirst() (T, error) {
+	if len(q.heap) == 0 {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	return q.removeAt(0).value, nil
+}
+
+// Offer adds a new element to the queue.
+// Returns a handle that can be used to change the priority of the element or to remove it.
+func (q *PriorityQueue[T]) Offer(value T) *Handle[T] {
+	h := &Handle[T]{value: value, index: len(q.heap), queue: q}
+	q.heap = append(q.heap, h)
+	q.up(h.index)
+	q.modCount++
+	return h
+}
+
+// Update replaces the element referred to by the handle and restores the heap order.
+// Returns an error if the handle does not refer to an element of this queue.
+func (q *PriorityQueue[T]) Update(h *Handle[T], value T) error {
+	if !q.owns(h) {
+		return ErrNoSuchElement
+	}
+	h.value = value
+	return q.Fix(h)
+}
+
+// Fix restores the heap order after the priority of the element referred to by the handle has
+// changed, e.g. because the element is a pointer to a value that was modified.
+// Returns an error if the handle does not refer to an element of this queue.
+func (q *PriorityQueue[T]) Fix(h *Handle[T]) error {
+	if !q.owns(h) {
+		return ErrNoSuchElement
+	}
+	if !q.down(h.index) {
+		q.up(h.index)
+	}
+	return nil
+}
+
+// Delete removes the element referred to by the handle.
+// Returns an error if the handle does not refer to an element of this queue.
+func (q *PriorityQueue[T]) Delete(h *Handle[T]) error {
+	if !q.owns(h) {
+		return ErrNoSuchElement
+	}
+	q.removeAt(h.index)
+	return nil
+}
+
+// Merge moves all elements of the other queue into this queue in O(n + m). The other queue is
+// left empty and handles to its elements now refer to elements of this queue.
+func (q *PriorityQueue[T]) Merge(other *PriorityQueue[T]) {
+	if other == q || len(other.heap) == 0 {
+		return
+	}
+	q.heap = append(q.heap, other.heap...)
+	other.heap = nil
+	other.modCount++
+	q.heapify()
+	q.modCount++
+}
+
+// priorityQueueIterator iterates over a snapshot of the elements in a priority queue.
+type priorityQueueIterator[T any] struct {
+	queue    *PriorityQueue[T]
+	handles  []*Handle[T]
+	index    int
+	removed  bool
+	expected int
+}
+
+func (i *priorityQueueIterator[T]) HasNext() bool {
+	return i.index < len(i.handles)
+}
+
+func (i *priorityQueueIterator[T]) Next() (T, error) {
+	var zero T
+	if i.queue.modCount != i.expected {
+		return zero, ErrConcurrentModification
+	}
+	if !i.HasNext() {
+		return zero, ErrNoSuchElement
+	}
+	i.index++
+	i.removed = false
+	return i.handles[i.index-1].value, nil
+}
+
+func (i *priorityQueueIterator[T]) Remove() error {
+	if i.queue.modCount != i.expected {
+		return ErrConcurrentModification
+	}
+	if i.index == 0 || i.removed {
+		return ErrIllegalState
+	}
+	i.queue.Delete(i.handles[i.index-1])
+	i.removed = true
+	i.expected = i.queue.modCount
+	return nil
+}
+
+// NewPriorityQueueOf initializes a queue that gets elements in the order defined by the supplied
+// comparator, smallest first.
+func NewPriorityQueueOf[T any](compare Comparator[T], options ...Option[T]) *PriorityQueue[T] {
+	return &PriorityQueue[T]{compare: compare, equality: newOptions(options).equality}
+}
+
+// NewPriorityQueueOfValues initializes a priority queue holding the supplied values in O(n).
+func NewPriorityQueueOfValues[T any](
+	compare Comparator[T], values []T, options ...Option[T],
+) *PriorityQueue[T] {
+	q := NewPriorityQueueOf(compare, options...)
+	q.heap = make([]*Handle[T], len(values))
+	for i, value := range values {
+		q.heap[i] = &Handle[T]{value: value}
+	}
+	q.heapify()
+	return q
+}
@@ -0,0 +1,157 @@
+package gollections_test
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// compareAny orders untyped integers.
+func compareAny(a, b any) int {
+	return cmp.Compare(a.(int), b.(int))
+}
+
+// Test the PriorityQueue as an implementation of Queue.
+func TestPriorityQueue(t *testing.T) {
+	testQueue(t, gollections.NewPriorityQueueOf(compareAny))
+}
+
+// TestPriorityQueueOrder tests that elements are popped in priority order.
+func TestPriorityQueueOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 200)
+	for i := range values {
+		values[i] = r.Intn(100)
+	}
+	for name, queue := range map[string]*gollections.PriorityQueue[int]{
+		"offer": gollections.NewPriorityQueueOf(cmp.Compare[int]),
+		"from":  gollections.NewPriorityQueueOfValues(cmp.Compare[int], values),
+	} {
+		if name == "offer" {
+			queue.Add(values...)
+		}
+		got := []int{}
+		for !queue.IsEmpty() {
+			value, err := queue.PopFirst()
+			if err != nil {
+				t.Fatalf("%s: expected no error, got %v", name, err)
+			}
+			got = append(got, value)
+		}
+		expected := append([]int{}, values...)
+		sort.Ints(expected)
+		if !reflect.DeepEqual(expected, got) {
+			t.Fatalf("%s: expected %v, got %v", name, expected, got)
+		}
+	}
+	// max heap
+	queue := gollections.NewPriorityQueueOfValues(func(a, b int) int { return b - a }, []int{3, 9, 1})
+	if got, err := queue.PeekFirst(); err != nil || got != 9 {
+		t.Fatalf("expected 9, got %d, err: %v", got, err)
+	}
+}
+
+// TestPriorityQueueHandles tests changing and removing elements through handles.
+func TestPriorityQueueHandles(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	queue := gollections.NewPriorityQueueOf(func(a, b *job) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+	a := queue.Offer(&job{"a", 5})
+	b := queue.Offer(&job{"b", 3})
+	c := queue.Offer(&job{"c", 4})
+	// update
+	if err := queue.Update(a, &job{"a", 1}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, _ := queue.PeekFirst(); got.name != "a" {
+		t.Fatalf("expected a, got %s", got.name)
+	}
+	// fix
+	a.Value().priority = 10
+	b.Value().priority = 20
+	queue.Fix(a)
+	queue.Fix(b)
+	if got, _ := queue.PeekFirst(); got.name != "c" {
+		t.Fatalf("expected c, got %s", got.name)
+	}
+	// delete
+	if err := queue.Delete(c); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := queue.Delete(c); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if err := queue.Fix(c); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	for _, expected := range []string{"a", "b"} {
+		if got, err := queue.PopFirst(); err != nil || got.name != expected {
+			t.Fatalf("expected %s, got %v, err: %v", expected, got, err)
+		}
+	}
+	if err := queue.Update(a, &job{"a", 1}); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+}
+
+// TestPriorityQueueMerge tests merging the elements of two priority queues.
+func TestPriorityQueueMerge(t *testing.T) {
+	queue := gollections.NewPriorityQueueOfValues(cmp.Compare[int], []int{5, 1, 9})
+	other := gollections.NewPriorityQueueOf(cmp.Compare[int])
+	other.Add(4, 8)
+	handle := other.Offer(7)
+	queue.Merge(other)
+	if !other.IsEmpty() {
+		t.Fatal("expected merged queue to be empty")
+	}
+	if err := queue.Update(handle, 0); err != nil {
+		t.Fatalf("expected handle to move to the merged queue, got %v", err)
+	}
+	expected := []int{0, 1, 4, 5, 8, 9}
+	got := []int{}
+	for !queue.IsEmpty() {
+		value, _ := queue.PopFirst()
+		got = append(got, value)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestPriorityQueueCollection tests the collection functions of the PriorityQueue.
+func TestPriorityQueueCollection(t *testing.T) {
+	queue := gollections.NewPriorityQueueOf(cmp.Compare[int])
+	queue.Add(4, 2, 6, 2, 8)
+	if !queue.Contains(2, 8) || queue.Contains(3) {
+		t.Fatal("unexpected contains result")
+	}
+	queue.Remove(2, 8)
+	got := queue.ToArray()
+	sort.Ints(got)
+	if expected := []int{4, 6}; !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	it := queue.Iterator()
+	// removing values that are not in the queue does not invalidate iterators
+	queue.Remove(3)
+	for it.HasNext() {
+		if value, _ := it.Next(); value == 4 {
+			it.Remove()
+		}
+	}
+	if got, err := queue.PeekFirst(); err != nil || got != 6 || queue.Size() != 1 {
+		t.Fatalf("expected 6, got %d, err: %v", got, err)
+	}
+	queue.Clear()
+	if _, err := queue.PopFirst(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package gollections
+
+// union adds the elements of both sets to the result.
+func union[T any](result, a, b SetOf[T]) SetOf[T] {
+	for value := range a.All() {
+		result.Add(value)
+	}
+	for value := range b.All() {
+		result.Add(value)
+	}
+	return result
+}
+
+// intersection adds the elements of the first set that are also in the second set to the result.
+func intersection[T any](result, a, b SetOf[T]) SetOf[T] {
+	for value := range a.All() {
+		if b.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+// difference adds the elements of the first set that are not in the second set to the result.
+func difference[T any](result, a, b SetOf[T]) SetOf[T] {
+	for value := range a.All() {
+		if !b.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+// symmetricDifference adds the elements that are in exactly one of the sets to the result.
+func symmetricDifference[T any](result, a, b SetOf[T]) SetOf[T] {
+	difference(result, a, b)
+	return difference(result, b, a)
+}
+
+// isSubset checks if every element in the first set is also in the second set.
+func isSubset[T any](a, b SetOf[T]) bool {
+	if a.Size() > b.Size() {
+		return false
+	}
+	for value := range a.All() {
+		if !b.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
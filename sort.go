@@ -0,0 +1,245 @@
+package gollections
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// compareFunc converts a less function to a function that orders two values.
+func compareFunc[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+// setAll overwrites the elements of a list, in order, with the supplied values.
+func setAll[T any](list ListOf[T], values []T) {
+	it := list.ListIterator()
+	for _, value := range values {
+		if _, err := it.Next(); err != nil {
+			panic(err)
+		}
+		if err := it.Set(value); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// split cuts a chain of nodes after the specified number of nodes.
+// Returns the first node after the cut, or nil if the chain is not longer than that.
+func split[T any](n *listNode[T], count int) *listNode[T] {
+	if n == nil {
+		return nil
+	}
+	for ; count > 1 && n.next != nil; count-- {
+		n = n.next
+	}
+	rest := n.next
+	n.next = nil
+	return rest
+}
+
+// merge links two sorted chains of nodes into a single sorted chain, taking nodes from the first
+// chain when elements are equal. Returns the first and last node of the merged chain.
+func merge[T any](a, b *listNode[T], less func(a, b T) bool) (*listNode[T], *listNode[T]) {
+	var head, tail *listNode[T]
+	for a != nil || b != nil {
+		var n *listNode[T]
+		if b == nil || (a != nil && !less(b.value, a.value)) {
+			n, a = a, a.next
+		} else {
+			n, b = b, b.next
+		}
+		if tail == nil {
+			head = n
+		} else {
+			tail.next = n
+		}
+		tail = n
+	}
+	return head, tail
+}
+
+// sort orders the list by relinking its nodes with a bottom up merge sort.
+// The sort is stable, takes O(n log n) time and does not allocate.
+func (l *LinkedList[T]) sort(less func(a, b T) bool) {
+	if l.length < 2 {
+		return
+	}
+	head := l.head
+	for width := 1; width < l.length; width *= 2 {
+		var sorted, tail *listNode[T]
+		for n := head; n != nil; {
+			left := n
+			right := split(left, width)
+			n = split(right, width)
+			first, last := merge(left, right, less)
+			if tail == nil {
+				sorted = first
+			} else {
+				tail.next = first
+			}
+			tail = last
+		}
+		head = sorted
+	}
+	var previous *listNode[T]
+	for n := head; n != nil; n = n.next {
+		n.previous = previous
+		previous = n
+	}
+	l.head = head
+	l.tail = previous
+	l.cursor = nil
+	l.modCount++
+}
+
+// reverse reverses the order of the list by swapping the links of each node.
+func (l *LinkedList[T]) reverse() {
+	for n := l.head; n != nil; n = n.previous {
+		n.next, n.previous = n.previous, n.next
+	}
+	l.head, l.tail = l.tail, l.head
+	l.cursor = nil
+	l.modCount++
+}
+
+// Sort orders the elements of a list using the supplied less function.
+// A LinkedList is sorted by relinking its nodes, an ArrayList or ArrayDeque is sorted in place and
+// any other list is sorted through its list iterator. The sort is not guaranteed to be stable.
+func Sort[T any](list ListOf[T], less func(a, b T) bool) {
+	switch list := list.(type) {
+	case *LinkedList[T]:
+		list.sort(less)
+	case *ArrayList[T]:
+		slices.SortFunc(list.values, compareFunc(less))
+		list.modCount++
+	case *ArrayDeque[T]:
+		slices.SortFunc(list.contiguous(), compareFunc(less))
+		list.modCount++
+	default:
+		values := list.ToArray()
+		slices.SortFunc(values, compareFunc(less))
+		setAll(list, values)
+	}
+}
+
+// SortStable orders the elements of a list using the supplied less function, keeping equal
+// elements in their original order.
+func SortStable[T any](list ListOf[T], less func(a, b T) bool) {
+	switch list := list.(type) {
+	case *LinkedList[T]:
+		list.sort(less)
+	case *ArrayList[T]:
+		slices.SortStableFunc(list.values, compareFunc(less))
+		list.modCount++
+	case *ArrayDeque[T]:
+		slices.SortStableFunc(list.contiguous(), compareFunc(less))
+		list.modCount++
+	default:
+		values := list.ToArray()
+		slices.SortStableFunc(values, compareFunc(less))
+		setAll(list, values)
+	}
+}
+
+// IsSorted checks if the elements of a list are ordered according to the supplied less function.
+func IsSorted[T any](list ListOf[T], less func(a, b T) bool) bool {
+	first := true
+	var previous T
+	for value := range list.All() {
+		if !first && less(value, previous) {
+			return false
+		}
+		previous, first = value, false
+	}
+	return true
+}
+
+// BinarySearch searches a list that is sorted according to the supplied less function for the
+// target value. Returns the index of the target, or the index at which it would be inserted, and
+// whether the target was found. A LinkedList is copied before searching as it cannot be accessed
+// by index efficiently.
+func BinarySearch[T any](list ListOf[T], target T, less func(a, b T) bool) (int, bool) {
+	compare := compareFunc(less)
+	switch l := list.(type) {
+	case *ArrayList[T]:
+		return slices.BinarySearchFunc(l.values, target, compare)
+	case *LinkedList[T]:
+		return slices.BinarySearchFunc(l.ToArray(), target, compare)
+	}
+	low, high := 0, list.Size()
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		value, err := list.Get(middle)
+		if err != nil {
+			panic(err)
+		}
+		if compare(value, target) < 0 {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	if low < list.Size() {
+		value, err := list.Get(low)
+		if err != nil {
+			panic(err)
+		}
+		return low, compare(value, target) == 0
+	}
+	return low, false
+}
+
+// Reverse reverses the order of the elements of a list.
+func Reverse[T any](list ListOf[T]) {
+	switch list := list.(type) {
+	case *LinkedList[T]:
+		list.reverse()
+	case *ArrayList[T]:
+		slices.Reverse(list.values)
+		list.modCount++
+	case *ArrayDeque[T]:
+		slices.Reverse(list.contiguous())
+		list.modCount++
+	default:
+		values := list.ToArray()
+		slices.Reverse(values)
+		setAll(list, values)
+	}
+}
+
+// Shuffle randomly reorders the elements of a list using the supplied source of randomness, or
+// the global source if it is nil.
+func Shuffle[T any](list ListOf[T], r *rand.Rand) {
+	shuffle := rand.Shuffle
+	if r != nil {
+		shuffle = r.Shuffle
+	}
+	var values []T
+	switch l := list.(type) {
+	case *ArrayList[T]:
+		values = l.values
+	case *ArrayDeque[T]:
+		values = l.contiguous()
+	default:
+		values = list.ToArray()
+	}
+	shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+	switch l := list.(type) {
+	case *ArrayList[T]:
+		l.modCount++
+	case *ArrayDeque[T]:
+		l.modCount++
+	default:
+		setAll(list, values)
+	}
+}
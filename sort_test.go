@@ -0,0 +1,177 @@
+package gollections_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// lessInt orders integers in ascending order.
+func lessInt(a, b int) bool {
+	return a < b
+}
+
+// opaqueList hides the implementation of a list so that the generic code paths are used.
+type opaqueList struct {
+	gollections.ListOf[int]
+}
+
+// sortableLists gets an instance of each list implementation for sort tests.
+func sortableLists() map[string]gollections.ListOf[int] {
+	wrapped := gollections.NewArrayDequeOfCapacity[int](8)
+	wrapped.Add(0, 0, 0, 0, 0)
+	for i := 0; i < 5; i++ {
+		wrapped.PopFirst()
+	}
+	return map[string]gollections.ListOf[int]{
+		"linked": gollections.NewLinkedListOf[int](),
+		"array":  gollections.NewArrayListOf[int](),
+		"deque":  wrapped,
+		"opaque": opaqueList{gollections.NewLinkedListOf[int]()},
+	}
+}
+
+// TestSort tests sorting each kind of list.
+func TestSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for name, list := range sortableLists() {
+		for _, n := range []int{0, 1, 2, 3, 7, 8, 100} {
+			list.Clear()
+			for i := 0; i < n; i++ {
+				list.Add(r.IntN(50))
+			}
+			expected := list.ToArray()
+			slices.Sort(expected)
+			gollections.Sort(list, lessInt)
+			if got := list.ToArray(); !reflect.DeepEqual(expected, got) && n > 0 {
+				t.Fatalf("%s: expected %v, got %v", name, expected, got)
+			}
+			if !gollections.IsSorted(list, lessInt) {
+				t.Fatalf("%s: expected list to be sorted", name)
+			}
+			// links are consistent in both directions
+			backward := []int{}
+			for value := range list.Backward() {
+				backward = append(backward, value)
+			}
+			slices.Reverse(backward)
+			if !reflect.DeepEqual(list.ToArray(), backward) && n > 0 {
+				t.Fatalf("%s: expected %v backward, got %v", name, list.ToArray(), backward)
+			}
+		}
+	}
+}
+
+// TestSortStable tests that stable sorting keeps equal elements in their original order.
+func TestSortStable(t *testing.T) {
+	type pair struct {
+		key, order int
+	}
+	less := func(a, b pair) bool { return a.key < b.key }
+	for _, list := range []gollections.ListOf[pair]{
+		gollections.NewLinkedListOf[pair](),
+		gollections.NewArrayListOf[pair](),
+		gollections.NewArrayDequeOfCapacity[pair](0),
+	} {
+		for i := 0; i < 50; i++ {
+			list.Add(pair{key: (i * 7) % 5, order: i})
+		}
+		gollections.SortStable(list, less)
+		previous := pair{key: -1}
+		for value := range list.All() {
+			if value.key < previous.key || (value.key == previous.key && value.order < previous.order) {
+				t.Fatalf("%T: expected stable order, got %v after %v", list, value, previous)
+			}
+			previous = value
+		}
+	}
+}
+
+// TestSortLinkedListAllocations tests that sorting a linked list relinks nodes without allocating.
+func TestSortLinkedListAllocations(t *testing.T) {
+	list := gollections.NewLinkedListOf[int]()
+	for i := 0; i < 1000; i++ {
+		list.Add(1000 - i)
+	}
+	reversed := false
+	allocs := testing.AllocsPerRun(10, func() {
+		reversed = !reversed
+		gollections.Sort(list, func(a, b int) bool { return (a < b) != reversed })
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+// TestSortConcurrentModification tests that sorting a list invalidates its iterators.
+func TestSortConcurrentModification(t *testing.T) {
+	list := gollections.NewLinkedListOf[int]()
+	list.Add(3, 1, 2)
+	it := list.Iterator()
+	gollections.Sort(list, lessInt)
+	if _, err := it.Next(); err != gollections.ErrConcurrentModification {
+		t.Fatalf("expected concurrent modification error, got %v", err)
+	}
+}
+
+// TestBinarySearch tests searching sorted lists.
+func TestBinarySearch(t *testing.T) {
+	for name, list := range sortableLists() {
+		list.Add(1, 3, 3, 5, 7)
+		cases := []struct {
+			target, index int
+			found         bool
+		}{
+			{0, 0, false}, {1, 0, true}, {3, 1, true}, {4, 3, false}, {7, 4, true}, {8, 5, false},
+		}
+		for _, c := range cases {
+			index, found := gollections.BinarySearch(list, c.target, lessInt)
+			if index != c.index || found != c.found {
+				t.Fatalf("%s: expected %d, %t for %d, got %d, %t",
+					name, c.index, c.found, c.target, index, found)
+			}
+		}
+	}
+}
+
+// TestReverse tests reversing each kind of list.
+func TestReverse(t *testing.T) {
+	for name, list := range sortableLists() {
+		list.Add(1, 2, 3, 4)
+		gollections.Reverse(list)
+		if got := list.ToArray(); !reflect.DeepEqual([]int{4, 3, 2, 1}, got) {
+			t.Fatalf("%s: expected [4 3 2 1], got %v", name, got)
+		}
+		if first, _ := list.Get(0); first != 4 {
+			t.Fatalf("%s: expected 4, got %d", name, first)
+		}
+		list.Add(0)
+		if got := list.ToArray(); !reflect.DeepEqual([]int{4, 3, 2, 1, 0}, got) {
+			t.Fatalf("%s: expected [4 3 2 1 0], got %v", name, got)
+		}
+	}
+}
+
+// TestShuffle tests that shuffling keeps the elements of each kind of list.
+func TestShuffle(t *testing.T) {
+	for name, list := range sortableLists() {
+		for i := 0; i < 20; i++ {
+			list.Add(i)
+		}
+		gollections.Shuffle(list, rand.New(rand.NewPCG(3, 4)))
+		got := list.ToArray()
+		if gollections.IsSorted(list, lessInt) {
+			t.Fatalf("%s: expected shuffled list, got %v", name, got)
+		}
+		slices.Sort(got)
+		for i, value := range got {
+			if value != i {
+				t.Fatalf("%s: expected all elements to be kept, got %v", name, got)
+			}
+		}
+		gollections.Shuffle(list, nil)
+	}
+}
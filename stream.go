@@ -0,0 +1,352 @@
+package gollections
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// A Stream is a lazy pipeline of operations over a sequence of elements.
+// Intermediate operations such as Filter, Limit and Sorted build a new stream without visiting
+// any elements; elements are only visited when a terminal operation such as Collect, Count,
+// ForEach or Reduce is called. Consecutive stateless operations (Filter, Peek and MapStream) are
+// applied to each element in a single pass, and Limit stops visiting elements as soon as enough
+// have been seen.
+type Stream[T any] struct {
+	source    func(config streamConfig) iter.Seq[interface{}]
+	transform func(interface{}) (T, bool)
+	config    streamConfig
+}
+
+// streamConfig holds the execution mode of a stream pipeline. Streams are immutable, so each
+// stream holds its own copy and passes it to the earlier stages of the pipeline when the stream
+// is run.
+type streamConfig struct {
+	workers int
+	ordered bool
+}
+
+// unbox converts an element of a stream source to the element type of the stream.
+func unbox[T any](value interface{}) (T, bool) {
+	// the ok result is ignored so that nil interface values convert to the zero value
+	v, _ := value.(T)
+	return v, true
+}
+
+// restart begins a new stage of the pipeline that reads the sequence created by the supplied
+// function for the execution mode the stream is run with.
+func (s *Stream[T]) restart(seq func(config streamConfig) iter.Seq[T]) *Stream[T] {
+	return &Stream[T]{
+		source: func(config streamConfig) iter.Seq[interface{}] {
+			return anySeq(seq(config))
+		},
+		transform: unbox[T],
+		config:    s.config,
+	}
+}
+
+// configure gets a copy of the stream that runs with the supplied execution mode.
+func (s *Stream[T]) configure(config streamConfig) *Stream[T] {
+	return &Stream[T]{source: s.source, transform: s.transform, config: config}
+}
+
+// then appends a stateless operation to the current stage of the pipeline.
+func (s *Stream[T]) then(transform func(T) (T, bool)) *Stream[T] {
+	previous := s.transform
+	return &Stream[T]{
+		source: s.source,
+		transform: func(value interface{}) (T, bool) {
+			v, ok := previous(value)
+			if !ok {
+				return v, false
+			}
+			return transform(v)
+		},
+		config: s.config,
+	}
+}
+
+// seq creates a sequence over the elements of the stream.
+func (s *Stream[T]) seq() iter.Seq[T] {
+	return s.run(s.config)
+}
+
+// run creates a sequence over the elements of the stream that runs every stage of the pipeline
+// with the supplied execution mode.
+func (s *Stream[T]) run(config streamConfig) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		source := s.source(config)
+		if config.workers > 1 {
+			parallel(source, s.transform, config.workers, config.ordered)(yield)
+			return
+		}
+		for value := range source {
+			if v, ok := s.transform(value); ok && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Filter gets a stream of the elements that match the predicate.
+func (s *Stream[T]) Filter(predicate func(T) bool) *Stream[T] {
+	return s.then(func(value T) (T, bool) {
+		return value, predicate(value)
+	})
+}
+
+// Limit gets a stream of at most the specified number of elements.
+func (s *Stream[T]) Limit(n int) *Stream[T] {
+	return s.restart(func(config streamConfig) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			if n <= 0 {
+				return
+			}
+			count := 0
+			for value := range s.run(config) {
+				count++
+				if !yield(value) || count == n {
+					return
+				}
+			}
+		}
+	})
+}
+
+// Ordered gets a stream that keeps elements in the order of its source when it is parallel. Has
+// no effect on a stream that is not parallel, which is always ordered.
+func (s *Stream[T]) Ordered() *Stream[T] {
+	config := s.config
+	config.ordered = true
+	return s.configure(config)
+}
+
+// Parallel gets a stream whose stateless operations run on the specified number of goroutines.
+// Elements are visited in the order they are processed unless Ordered is called. Terminal
+// operations still run on the calling goroutine. The mode applies to every stage of the pipeline
+// of the returned stream, the stream it is called on is unchanged; a number less than two makes
+// the stream sequential.
+func (s *Stream[T]) Parallel(n int) *Stream[T] {
+	config := s.config
+	config.workers = n
+	return s.configure(config)
+}
+
+// Peek gets a stream that calls the supplied function on each element as it is visited.
+// For a parallel stream the function is called concurrently.
+func (s *Stream[T]) Peek(action func(T)) *Stream[T] {
+	return s.then(func(value T) (T, bool) {
+		action(value)
+		return value, true
+	})
+}
+
+// Skip gets a stream without the specified number of leading elements.
+func (s *Stream[T]) Skip(n int) *Stream[T] {
+	return s.restart(func(config streamConfig) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			count := 0
+			for value := range s.run(config) {
+				count++
+				if count > n && !yield(value) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// Sorted gets a stream of the elements in the order defined by the comparator. Elements that are
+// equal keep their relative order. All elements are visited before the first element is
+// returned.
+func (s *Stream[T]) Sorted(compare Comparator[T]) *Stream[T] {
+	return s.restart(func(config streamConfig) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			values := slices.Collect(s.run(config))
+			slices.SortStableFunc(values, compare)
+			for _, value := range values {
+				if !yield(value) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// All gets a sequence over the elements of the stream for use in range loops.
+func (s *Stream[T]) All() iter.Seq[T] {
+	return s.seq()
+}
+
+// Collect gets a new list holding the elements of the stream.
+func (s *Stream[T]) Collect() ListOf[T] {
+	return &ArrayList[T]{values: slices.Collect(s.seq())}
+}
+
+// Count gets the number of elements in the stream.
+func (s *Stream[T]) Count() int {
+	count := 0
+	for range s.seq() {
+		count++
+	}
+	return count
+}
+
+// ForEach calls the supplied function on each element of the stream.
+func (s *Stream[T]) ForEach(action func(T)) {
+	for value := range s.seq() {
+		action(value)
+	}
+}
+
+// Reduce combines the elements of the stream from first to last using the supplied function.
+// Returns an error if the stream is empty.
+func (s *Stream[T]) Reduce(combine func(T, T) T) (T, error) {
+	var result T
+	first := true
+	for value := range s.seq() {
+		if first {
+			result, first = value, false
+			continue
+		}
+		result = combine(result, value)
+	}
+	if first {
+		return result, ErrNoSuchElement
+	}
+	return result, nil
+}
+
+// MapStream gets a stream of the result of applying the mapper to each element of the supplied
+// stream.
+func MapStream[T, U any](s *Stream[T], mapper func(T) U) *Stream[U] {
+	previous := s.transform
+	return &Stream[U]{
+		source: s.source,
+		transform: func(value interface{}) (U, bool) {
+			v, ok := previous(value)
+			if !ok {
+				var zero U
+				return zero, false
+			}
+			return mapper(v), true
+		},
+		config: s.config,
+	}
+}
+
+// parallel creates a sequence that applies a transform to the elements of the source on the
+// specified number of goroutines. The source is read on its own goroutine. Panics raised by the
+// source or the transform are raised again on the goroutine that reads the sequence.
+func parallel[T any](
+	source iter.Seq[interface{}], transform func(interface{}) (T, bool), workers int, ordered bool,
+) iter.Seq[T] {
+	type task struct {
+		index int
+		value interface{}
+	}
+	type result struct {
+		index int
+		value T
+		ok    bool
+		panic interface{}
+	}
+	return func(yield func(T) bool) {
+		tasks := make(chan task)
+		results := make(chan result, workers)
+		failed := make(chan interface{}, 1)
+		done := make(chan struct{})
+		go func() {
+			defer close(tasks)
+			defer func() {
+				if r := recover(); r != nil {
+					failed <- r
+				}
+			}()
+			index := 0
+			for value := range source {
+				select {
+				case tasks <- task{index: index, value: value}:
+				case <-done:
+					return
+				}
+				index++
+			}
+		}()
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for t := range tasks {
+					r := result{index: t.index}
+					func() {
+						defer func() {
+							r.panic = recover()
+						}()
+						r.value, r.ok = transform(t.value)
+					}()
+					select {
+					case results <- r:
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+		defer func() {
+			close(done)
+			for range results {
+			}
+		}()
+		pending := map[int]result{}
+		next := 0
+		for r := range results {
+			if r.panic != nil {
+				panic(r.panic)
+			}
+			if !ordered {
+				if r.ok && !yield(r.value) {
+					return
+				}
+				continue
+			}
+			pending[r.index] = r
+			for r, ok := pending[next]; ok; r, ok = pending[next] {
+				delete(pending, next)
+				next++
+				if r.ok && !yield(r.value) {
+					return
+				}
+			}
+		}
+		select {
+		case r := <-failed:
+			panic(r)
+		default:
+		}
+	}
+}
+
+// NewStreamOf initializes a stream over the elements of a collection.
+func NewStreamOf[T any](c CollectionOf[T]) *Stream[T] {
+	return NewStreamOfSeq(c.All())
+}
+
+// NewStreamOfValues initializes a stream over the supplied values, e.g. the completions returned
+// by a trie.
+func NewStreamOfValues[T any](values ...T) *Stream[T] {
+	return NewStreamOfSeq(slices.Values(values))
+}
+
+// NewStreamOfSeq initializes a stream over the values of a sequence.
+func NewStreamOfSeq[T any](seq iter.Seq[T]) *Stream[T] {
+	source := func(streamConfig) iter.Seq[interface{}] {
+		return anySeq(seq)
+	}
+	return &Stream[T]{source: source, transform: unbox[T]}
+}
@@ -0,0 +1,156 @@
+package gollections_test
+
+import (
+	"cmp"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsladewski/gollections"
+	"github.com/bsladewski/gollections/trie"
+)
+
+// TestStream tests chaining stream operations.
+func TestStream(t *testing.T) {
+	list := gollections.NewLinkedListOf[int]()
+	list.Add(9, 4, 7, 2, 8, 1, 6)
+	visited := 0
+	stream := gollections.NewStreamOf(list).
+		Peek(func(int) { visited++ }).
+		Filter(isEven).
+		Sorted(cmp.Compare[int]).
+		Skip(1)
+	if visited != 0 {
+		t.Fatalf("expected no elements to be visited before a terminal operation, got %d", visited)
+	}
+	if got := stream.Collect().ToArray(); !reflect.DeepEqual([]int{4, 6, 8}, got) {
+		t.Fatalf("expected [4 6 8], got %v", got)
+	}
+	if visited != 7 {
+		t.Fatalf("expected 7 elements to be visited, got %d", visited)
+	}
+	if count := stream.Count(); count != 3 {
+		t.Fatalf("expected 3, got %d", count)
+	}
+	lengths := gollections.MapStream(gollections.NewStreamOfValues("a", "bbb", "cc"),
+		func(value string) int { return len(value) })
+	if sum, err := lengths.Reduce(func(a, b int) int { return a + b }); err != nil || sum != 6 {
+		t.Fatalf("expected 6, got %d, err: %v", sum, err)
+	}
+	if _, err := gollections.NewStreamOfValues[int]().Reduce(func(a, b int) int { return a + b }); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	got := []string{}
+	gollections.NewStreamOfValues("x", "y", "z").Limit(2).ForEach(func(value string) {
+		got = append(got, value)
+	})
+	if !reflect.DeepEqual([]string{"x", "y"}, got) {
+		t.Fatalf("expected [x y], got %v", got)
+	}
+}
+
+// TestStreamShortCircuit tests that Limit stops visiting elements.
+func TestStreamShortCircuit(t *testing.T) {
+	visited := 0
+	infinite := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			visited++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	got := gollections.NewStreamOfSeq(infinite).Filter(isEven).Limit(3).Collect().ToArray()
+	if !reflect.DeepEqual([]int{0, 2, 4}, got) {
+		t.Fatalf("expected [0 2 4], got %v", got)
+	}
+	if visited != 5 {
+		t.Fatalf("expected 5 elements to be visited, got %d", visited)
+	}
+	if count := gollections.NewStreamOfSeq(infinite).Limit(0).Count(); count != 0 {
+		t.Fatalf("expected 0, got %d", count)
+	}
+}
+
+// TestStreamTrie tests streaming the completions of a trie.
+func TestStreamTrie(t *testing.T) {
+	words := trie.NewTrieOf[string]()
+	words.Add("go", "gopher", "golang", "rust")
+	upper := gollections.MapStream(gollections.NewStreamOfValues(words.Complete("go")...), strings.ToUpper)
+	got := upper.Sorted(strings.Compare).Collect().ToArray()
+	if !reflect.DeepEqual([]string{"GO", "GOLANG", "GOPHER"}, got) {
+		t.Fatalf("expected [GO GOLANG GOPHER], got %v", got)
+	}
+}
+
+// TestStreamParallel tests running stream operations on multiple goroutines.
+func TestStreamParallel(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	var peeked atomic.Int64
+	squares := gollections.MapStream(
+		gollections.NewStreamOfValues(values...).Parallel(4).Peek(func(int) { peeked.Add(1) }),
+		func(value int) int { return value * value },
+	)
+	unordered := squares.Collect().ToArray()
+	if len(unordered) != 1000 || peeked.Load() != 1000 {
+		t.Fatalf("expected 1000 elements, got %d", len(unordered))
+	}
+	sort.Ints(unordered)
+	for i, value := range unordered {
+		if value != i*i {
+			t.Fatalf("expected %d, got %d", i*i, value)
+		}
+	}
+	ordered := squares.Ordered().Filter(isEven).Collect().ToArray()
+	for i, value := range ordered {
+		if value != (2*i)*(2*i) {
+			t.Fatalf("expected %d at index %d, got %d", (2*i)*(2*i), i, value)
+		}
+	}
+	limited := gollections.NewStreamOfValues(values...).Parallel(8).Ordered().Limit(10).Collect().ToArray()
+	if !reflect.DeepEqual(values[:10], limited) {
+		t.Fatalf("expected %v, got %v", values[:10], limited)
+	}
+	// panics are raised on the calling goroutine
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("expected panic boom, got %v", r)
+		}
+	}()
+	gollections.NewStreamOfValues(values...).Parallel(4).Peek(func(value int) {
+		if value == 500 {
+			panic("boom")
+		}
+	}).Count()
+}
+
+// TestStreamImmutable tests that changing the execution mode of a derived stream does not change
+// the stream it was derived from.
+func TestStreamImmutable(t *testing.T) {
+	var active, overlaps atomic.Int32
+	base := gollections.NewStreamOfValues(1, 2, 3, 4, 5, 6, 7, 8).Peek(func(int) {
+		if active.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+	})
+	limited := base.Limit(8)
+	if count := limited.Parallel(4).Ordered().Count(); count != 8 {
+		t.Fatalf("expected 8 elements, got %d", count)
+	}
+	overlaps.Store(0)
+	if got := limited.Collect().ToArray(); !reflect.DeepEqual([]int{1, 2, 3, 4, 5, 6, 7, 8}, got) {
+		t.Fatalf("expected elements in order, got %v", got)
+	}
+	if base.Count() != 8 || overlaps.Load() != 0 {
+		t.Fatalf("expected sequential streams to visit one element at a time, got %d overlaps",
+			overlaps.Load())
+	}
+}
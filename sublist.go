@@ -0,0 +1,309 @@
+package gollections
+
+import "iter"
+
+// subList is a view of a range of the elements of a list. All operations are translated to
+// operations on the root list, so the view supports every kind of list. A view of a view keeps a
+// reference to its parent so that structural changes made through it update the size of every
+// enclosing view.
+type subList[T any] struct {
+	root     ListOf[T]
+	parent   *subList[T]
+	modCount *int
+	expected int
+	offset   int
+	size     int
+}
+
+// newSubList initializes a view of a range of the root list, or of the parent view if it is not
+// nil. The modCount must be incremented by every structural modification of the root list.
+func newSubList[T any](root ListOf[T], modCount *int, parent *subList[T], from, to int) (ListOf[T], error) {
+	offset, size := 0, root.Size()
+	if parent != nil {
+		if err := parent.checkModification(); err != nil {
+			return nil, err
+		}
+		offset, size = parent.offset, parent.size
+	}
+	if from < 0 || to > size || from > to {
+		return nil, ErrIndexOutOfBounds
+	}
+	return &subList[T]{
+		root:     root,
+		parent:   parent,
+		modCount: modCount,
+		expected: *modCount,
+		offset:   offset + from,
+		size:     to - from,
+	}, nil
+}
+
+// equalityStrategy gets the equality strategy of the root list.
+func (l *subList[T]) equalityStrategy() Equaler[T] {
+	return equalityOf(l.root)
+}
+
+// emptyCollection initializes an empty list of the same kind as the root list.
+func (l *subList[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return emptyLike(l.root, bounded)
+}
+
+// checkModification verifies that the root list has not been modified outside of the view.
+func (l *subList[T]) checkModification() error {
+	if *l.modCount != l.expected {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+// mustCheckModification panics if the root list has been modified outside of the view. Used by
+// methods that cannot return an error.
+func (l *subList[T]) mustCheckModification() {
+	if err := l.checkModification(); err != nil {
+		panic(err)
+	}
+}
+
+// resized records a structural modification made through the view in the view and all enclosing
+// views.
+func (l *subList[T]) resized(delta int) {
+	for v := l; v != nil; v = v.parent {
+		v.size += delta
+		v.expected = *v.modCount
+	}
+}
+
+// insertAt adds elements to the root list before the element at the specified index of the
+// view, or after the last element of the view if the index is the size of the view. Returns a
+// capacity exceeded error if the root list is a fixed size deque without space for the elements,
+// which would otherwise make room by dropping elements from its head.
+func (l *subList[T]) insertAt(index int, values []T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if d, ok := l.root.(*ArrayDeque[T]); ok && !d.ensureCapacity(len(values)) {
+		return ErrCapacityExceeded
+	}
+	var err error
+	if at := l.offset + index; at == l.root.Size() {
+		l.root.Add(values...)
+	} else {
+		err = l.root.Insert(at, values...)
+	}
+	if err != nil {
+		return err
+	}
+	l.resized(len(values))
+	return nil
+}
+
+// Add appends new elements to the end of the view.
+func (l *subList[T]) Add(values ...T) {
+	l.mustCheckModification()
+	if err := l.insertAt(l.size, values); err != nil {
+		panic(err)
+	}
+}
+
+// All gets a sequence over the elements of the view for use in range loops.
+func (l *subList[T]) All() iter.Seq[T] {
+	return forward(l.Iterator)
+}
+
+// Clear removes all elements in the view from the list.
+func (l *subList[T]) Clear() {
+	if err := l.RemoveRange(0, l.Size()); err != nil {
+		panic(err)
+	}
+}
+
+// Contains checks if the view contains all specified values.
+func (l *subList[T]) Contains(values ...T) bool {
+	return containsAll(equalityOf(l.root), l.ToArray(), values)
+}
+
+// IsEmpty checks if the view contains no elements.
+func (l *subList[T]) IsEmpty() bool {
+	return l.Size() == 0
+}
+
+// Iterator gets an iterator over the elements of the view.
+func (l *subList[T]) Iterator() Iterator[T] {
+	return l.ListIterator()
+}
+
+// Remove removes all specified values from the view.
+func (l *subList[T]) Remove(values ...T) {
+	l.RemoveIf(matcher(equalityOf(l.root), values))
+}
+
+// Size gets the number of elements in the view.
+func (l *subList[T]) Size() int {
+	l.mustCheckModification()
+	return l.size
+}
+
+// SliceCopy copies all values in the view to the supplied slice.
+func (l *subList[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, l.ToArray())
+}
+
+// ToArray gets an array representation of the view.
+func (l *subList[T]) ToArray() []T {
+	l.mustCheckModification()
+	array := make([]T, 0, l.size)
+	for value := range l.All() {
+		array = append(array, value)
+	}
+	return array
+}
+
+// AddAll appends the elements of the other collection to the end of the view.
+func (l *subList[T]) AddAll(other CollectionOf[T]) {
+	l.Add(other.ToArray()...)
+}
+
+// Backward gets a sequence over the elements of the view in reverse order.
+func (l *subList[T]) Backward() iter.Seq[T] {
+	return backward(func() ListIterator[T] {
+		return newIndexIterator[T](l, l.modCount, l.Size())
+	})
+}
+
+// IndexOf gets the first occurance of the specified value in the view or -1 if not found.
+func (l *subList[T]) IndexOf(value T) int {
+	equality := equalityOrDefault(equalityOf(l.root))
+	index := 0
+	for v := range l.All() {
+		if equality.Equal(value, v) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// Insert adds elements at the specified index of the view. Can return index not found error.
+func (l *subList[T]) Insert(index int, values ...T) error {
+	if err := l.checkModification(); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if index < 0 || index >= l.size {
+		return ErrIndexOutOfBounds
+	}
+	return l.insertAt(index, values)
+}
+
+// InsertAll adds the elements of the other collection at the specified index of the view.
+func (l *subList[T]) InsertAll(index int, other CollectionOf[T]) error {
+	return l.Insert(index, other.ToArray()...)
+}
+
+// Get retrieves the value of the element at the specified index of the view.
+func (l *subList[T]) Get(index int) (T, error) {
+	var zero T
+	if err := l.checkModification(); err != nil {
+		return zero, err
+	}
+	if index < 0 || index >= l.size {
+		return zero, ErrIndexOutOfBounds
+	}
+	return l.root.Get(l.offset + index)
+}
+
+// ListIterator gets a list iterator with its cursor before the first element of the view.
+func (l *subList[T]) ListIterator() ListIterator[T] {
+	return newIndexIterator[T](l, l.modCount, 0)
+}
+
+// RemoveAt removes the element at the specified index of the view.
+func (l *subList[T]) RemoveAt(index int) error {
+	if err := l.checkModification(); err != nil {
+		return err
+	}
+	if index < 0 || index >= l.size {
+		return ErrIndexOutOfBounds
+	}
+	if err := l.root.RemoveAt(l.offset + index); err != nil {
+		return err
+	}
+	l.resized(-1)
+	return nil
+}
+
+// RemoveIf removes all elements of the view that match the predicate.
+func (l *subList[T]) RemoveIf(predicate func(T) bool) {
+	it := l.ListIterator()
+	for it.HasNext() {
+		value, err := it.Next()
+		if err != nil {
+			panic(err)
+		}
+		if predicate(value) {
+			if err := it.Remove(); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// RemoveRange removes the elements of the view from the first index, inclusive, to the second
+// index, exclusive. Can return index not found error.
+func (l *subList[T]) RemoveRange(from, to int) error {
+	if err := l.checkModification(); err != nil {
+		return err
+	}
+	if from < 0 || to > l.size || from > to {
+		return ErrIndexOutOfBounds
+	}
+	if from == to {
+		return nil
+	}
+	if err := l.root.RemoveRange(l.offset+from, l.offset+to); err != nil {
+		return err
+	}
+	l.resized(from - to)
+	return nil
+}
+
+// ReplaceAll overwrites each element of the view with the result of applying the operator to it.
+func (l *subList[T]) ReplaceAll(operator func(T) T) {
+	it := l.ListIterator()
+	for it.HasNext() {
+		value, err := it.Next()
+		if err != nil {
+			panic(err)
+		}
+		if err := it.Set(operator(value)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RetainAll removes all elements of the view that are not in the other collection.
+func (l *subList[T]) RetainAll(other CollectionOf[T]) {
+	contains := membership(equalityOf(l.root), other)
+	l.RemoveIf(func(value T) bool {
+		return !contains(value)
+	})
+}
+
+// Set overwrites the value of the element at the specified index of the view.
+func (l *subList[T]) Set(index int, value T) error {
+	if err := l.checkModification(); err != nil {
+		return err
+	}
+	if index < 0 || index >= l.size {
+		return ErrIndexOutOfBounds
+	}
+	return l.root.Set(l.offset+index, value)
+}
+
+// SubList gets a view of the elements of this view from the first index, inclusive, to the
+// second index, exclusive.
+func (l *subList[T]) SubList(from, to int) (ListOf[T], error) {
+	return newSubList(l.root, l.modCount, l, from, to)
+}
@@ -0,0 +1,660 @@
+package gollections
+
+import (
+	"iter"
+	"sync"
+)
+
+// synchronizedCollection guards a collection with a read-write mutex. Methods that only read the
+// collection hold the read lock, all other methods hold the write lock. Iteration visits a
+// snapshot of the collection taken under the read lock, so iterating never blocks writers and
+// never fails due to concurrent modification.
+type synchronizedCollection[T any] struct {
+	mutex      *sync.RWMutex
+	collection CollectionOf[T]
+	exclusive  bool
+}
+
+// newSynchronizedCollection initializes a wrapper that guards a collection with the supplied
+// mutex. Reads take the write lock if reading the collection may update its internal state.
+func newSynchronizedCollection[T any](collection CollectionOf[T], mutex *sync.RWMutex) synchronizedCollection[T] {
+	return synchronizedCollection[T]{mutex: mutex, collection: collection, exclusive: readsModify(collection)}
+}
+
+// readsModify reports whether reading a collection may update its internal state. A view of a
+// LinkedList reads the list by index, which remembers the position of the last access.
+func readsModify[T any](collection CollectionOf[T]) bool {
+	view, ok := collection.(*subList[T])
+	if !ok {
+		return false
+	}
+	_, ok = view.root.(*LinkedList[T])
+	return ok
+}
+
+// rlock takes the lock for a method that only reads the collection. The write lock is taken
+// instead if reading may update the internal state of the collection.
+func (c *synchronizedCollection[T]) rlock() {
+	if c.exclusive {
+		c.mutex.Lock()
+	} else {
+		c.mutex.RLock()
+	}
+}
+
+// runlock releases the lock taken by rlock.
+func (c *synchronizedCollection[T]) runlock() {
+	if c.exclusive {
+		c.mutex.Unlock()
+	} else {
+		c.mutex.RUnlock()
+	}
+}
+
+// snapshot gets a copy of the collection of the same kind. Used to read the other operand of
+// bulk operations before the lock of the receiver is taken, which avoids holding two locks at
+// once.
+func (c *synchronizedCollection[T]) snapshot() CollectionOf[T] {
+	c.rlock()
+	defer c.runlock()
+	result := emptyLike(c.collection, true)
+	result.Add(c.collection.ToArray()...)
+	return result
+}
+
+// empty initializes an empty collection of the same kind and configuration as the underlying
+// collection, with the same capacity only if bounded is true.
+func (c *synchronizedCollection[T]) empty(bounded bool) CollectionOf[T] {
+	c.rlock()
+	defer c.runlock()
+	return emptyLike(c.collection, bounded)
+}
+
+// equalityStrategy gets the equality strategy of the underlying collection.
+func (c *synchronizedCollection[T]) equalityStrategy() Equaler[T] {
+	return equalityOf(c.collection)
+}
+
+// unshared gets a copy of the supplied collection if it is guarded by a lock, otherwise the
+// collection itself.
+func unshared[T any](other CollectionOf[T]) CollectionOf[T] {
+	if s, ok := other.(interface{ snapshot() CollectionOf[T] }); ok {
+		return s.snapshot()
+	}
+	return other
+}
+
+// Add appends new elements to the end of the collection.
+func (c *synchronizedCollection[T]) Add(values ...T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.collection.Add(values...)
+}
+
+// All gets a sequence over a snapshot of the elements of the collection.
+func (c *synchronizedCollection[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range c.ToArray() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all elements from the collection.
+func (c *synchronizedCollection[T]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.collection.Clear()
+}
+
+// Contains checks if the collection contains all specified values.
+func (c *synchronizedCollection[T]) Contains(values ...T) bool {
+	c.rlock()
+	defer c.runlock()
+	return c.collection.Contains(values...)
+}
+
+// IsEmpty checks if the collection contains no elements.
+func (c *synchronizedCollection[T]) IsEmpty() bool {
+	c.rlock()
+	defer c.runlock()
+	return c.collection.IsEmpty()
+}
+
+// Iterator gets an iterator over a snapshot of the elements of the collection. The iterator
+// cannot remove elements; use Do to iterate and modify the collection atomically.
+func (c *synchronizedCollection[T]) Iterator() Iterator[T] {
+	return newSnapshotIterator(c.ToArray(), 0, nil)
+}
+
+// Remove removes all specified values from the collection.
+func (c *synchronizedCollection[T]) Remove(values ...T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.collection.Remove(values...)
+}
+
+// Size gets the number of elements in the collection.
+func (c *synchronizedCollection[T]) Size() int {
+	c.rlock()
+	defer c.runlock()
+	return c.collection.Size()
+}
+
+// SliceCopy copies all values in the collection to the supplied slice.
+func (c *synchronizedCollection[T]) SliceCopy(ptrToSlice interface{}) error {
+	return sliceCopy(ptrToSlice, c.ToArray())
+}
+
+// ToArray gets an array representation of the collection.
+func (c *synchronizedCollection[T]) ToArray() []T {
+	c.rlock()
+	defer c.runlock()
+	return c.collection.ToArray()
+}
+
+// SyncCollection is a collection that may be shared between goroutines.
+type SyncCollection[T any] struct {
+	synchronizedCollection[T]
+}
+
+// emptyCollection initializes an empty synchronized collection that guards an empty collection of
+// the same kind.
+func (c *SyncCollection[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return SynchronizedCollection(c.empty(bounded))
+}
+
+// Do calls the supplied function with the underlying collection while holding the write lock, so
+// that compound operations are applied atomically. The function must not retain the collection
+// or call methods of the SyncCollection.
+func (c *SyncCollection[T]) Do(action func(CollectionOf[T])) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	action(c.collection)
+}
+
+// SyncList is a list that may be shared between goroutines.
+// Access by index holds the write lock, as a LinkedList remembers the position of the last
+// access to speed up the next one.
+type SyncList[T any] struct {
+	synchronizedCollection[T]
+	list ListOf[T]
+}
+
+// emptyCollection initializes an empty synchronized list that guards an empty list of the
+// same kind.
+func (c *SyncList[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return Synchronized(c.empty(bounded).(ListOf[T]))
+}
+
+// AddAll appends the elements of the other collection to the end of the list.
+func (l *SyncList[T]) AddAll(other CollectionOf[T]) {
+	l.Add(other.ToArray()...)
+}
+
+// Backward gets a sequence over a snapshot of the elements of the list in reverse order.
+func (l *SyncList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		values := l.ToArray()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// IndexOf gets the first occurance of the specified value or -1 if not found.
+func (l *SyncList[T]) IndexOf(value T) int {
+	l.rlock()
+	defer l.runlock()
+	return l.list.IndexOf(value)
+}
+
+// Insert adds elements at the specified index. Can return index not found error.
+func (l *SyncList[T]) Insert(index int, values ...T) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.list.Insert(index, values...)
+}
+
+// InsertAll adds the elements of the other collection at the specified index. Can return index
+// not found error.
+func (l *SyncList[T]) InsertAll(index int, other CollectionOf[T]) error {
+	return l.Insert(index, other.ToArray()...)
+}
+
+// Get retrieves the value of the element at the specified index.
+func (l *SyncList[T]) Get(index int) (T, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.list.Get(index)
+}
+
+// ListIterator gets a list iterator over a snapshot of the elements of the list. The iterator
+// cannot modify the list; use Do to iterate and modify the list atomically.
+func (l *SyncList[T]) ListIterator() ListIterator[T] {
+	return newSnapshotIterator(l.ToArray(), 0, nil)
+}
+
+// RemoveAt removes the element at the specified index.
+func (l *SyncList[T]) RemoveAt(index int) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.list.RemoveAt(index)
+}
+
+// RemoveIf removes all elements that match the predicate. The predicate is called while holding
+// the write lock and must not call methods of the list.
+func (l *SyncList[T]) RemoveIf(predicate func(T) bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.list.RemoveIf(predicate)
+}
+
+// RemoveRange removes the elements from the first index, inclusive, to the second index,
+// exclusive. Can return index not found error.
+func (l *SyncList[T]) RemoveRange(from, to int) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.list.RemoveRange(from, to)
+}
+
+// ReplaceAll overwrites each element with the result of applying the operator to it. The
+// operator is called while holding the write lock and must not call methods of the list.
+func (l *SyncList[T]) ReplaceAll(operator func(T) T) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.list.ReplaceAll(operator)
+}
+
+// RetainAll removes all elements that are not in the other collection.
+func (l *SyncList[T]) RetainAll(other CollectionOf[T]) {
+	other = unshared(other)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.list.RetainAll(other)
+}
+
+// Set overwrites the value of the element at the specified index.
+func (l *SyncList[T]) Set(index int, value T) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.list.Set(index, value)
+}
+
+// SubList gets a view of the elements from the first index, inclusive, to the second index,
+// exclusive. The view is guarded by the same lock as the list. Reading a view of a LinkedList
+// holds the write lock, as the view reads the list by index.
+func (l *SyncList[T]) SubList(from, to int) (ListOf[T], error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	view, err := l.list.SubList(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return newSyncList(view, l.mutex), nil
+}
+
+// Do calls the supplied function with the underlying list while holding the write lock, so that
+// compound operations are applied atomically. The function must not retain the list or call
+// methods of the SyncList.
+func (l *SyncList[T]) Do(action func(ListOf[T])) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	action(l.list)
+}
+
+// SyncQueue is a queue that may be shared between goroutines.
+type SyncQueue[T any] struct {
+	synchronizedCollection[T]
+	queue QueueOf[T]
+}
+
+// emptyCollection initializes an empty synchronized queue that guards an empty queue of the
+// same kind.
+func (c *SyncQueue[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return SynchronizedQueue(c.empty(bounded).(QueueOf[T]))
+}
+
+// PeekFirst gets the value of the first element in the collection.
+func (q *SyncQueue[T]) PeekFirst() (T, error) {
+	q.rlock()
+	defer q.runlock()
+	return q.queue.PeekFirst()
+}
+
+// PopFirst gets the value of the first element in the collection. The element is removed.
+func (q *SyncQueue[T]) PopFirst() (T, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.queue.PopFirst()
+}
+
+// Do calls the supplied function with the underlying queue while holding the write lock, so that
+// compound operations are applied atomically. The function must not retain the queue or call
+// methods of the SyncQueue.
+func (q *SyncQueue[T]) Do(action func(QueueOf[T])) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	action(q.queue)
+}
+
+// SyncDeque is a deque that may be shared between goroutines.
+type SyncDeque[T any] struct {
+	synchronizedCollection[T]
+	deque DequeOf[T]
+}
+
+// emptyCollection initializes an empty synchronized deque that guards an empty deque of the
+// same kind.
+func (c *SyncDeque[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return SynchronizedDeque(c.empty(bounded).(DequeOf[T]))
+}
+
+// AddFirst adds new elements to the beginning of the collection.
+func (d *SyncDeque[T]) AddFirst(values ...T) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.deque.AddFirst(values...)
+}
+
+// Backward gets a sequence over a snapshot of the elements of the deque from last to first.
+func (d *SyncDeque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		values := d.ToArray()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// PeekFirst gets the value of the first element in the collection.
+func (d *SyncDeque[T]) PeekFirst() (T, error) {
+	d.rlock()
+	defer d.runlock()
+	return d.deque.PeekFirst()
+}
+
+// PeekLast gets the value of the last element in the collection.
+func (d *SyncDeque[T]) PeekLast() (T, error) {
+	d.rlock()
+	defer d.runlock()
+	return d.deque.PeekLast()
+}
+
+// PopFirst gets the value of the first element in the collection. The element is removed.
+func (d *SyncDeque[T]) PopFirst() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.deque.PopFirst()
+}
+
+// PopLast gets the value of the last element in the collection. The element is removed.
+func (d *SyncDeque[T]) PopLast() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.deque.PopLast()
+}
+
+// Do calls the supplied function with the underlying deque while holding the write lock, so that
+// compound operations are applied atomically. The function must not retain the deque or call
+// methods of the SyncDeque.
+func (d *SyncDeque[T]) Do(action func(DequeOf[T])) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	action(d.deque)
+}
+
+// SyncStack is a stack that may be shared between goroutines.
+type SyncStack[T any] struct {
+	synchronizedCollection[T]
+	stack StackOf[T]
+}
+
+// emptyCollection initializes an empty synchronized stack that guards an empty stack of the
+// same kind.
+func (c *SyncStack[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return SynchronizedStack(c.empty(bounded).(StackOf[T]))
+}
+
+// PeekLast gets the value of the last element in the collection.
+func (s *SyncStack[T]) PeekLast() (T, error) {
+	s.rlock()
+	defer s.runlock()
+	return s.stack.PeekLast()
+}
+
+// PopLast gets the value of the last element in the collection. The element is removed.
+func (s *SyncStack[T]) PopLast() (T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.stack.PopLast()
+}
+
+// Do calls the supplied function with the underlying stack while holding the write lock, so that
+// compound operations are applied atomically. The function must not retain the stack or call
+// methods of the SyncStack.
+func (s *SyncStack[T]) Do(action func(StackOf[T])) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	action(s.stack)
+}
+
+// SyncSet is a set that may be shared between goroutines. Set operations return a new set that
+// is not synchronized.
+type SyncSet[T any] struct {
+	synchronizedCollection[T]
+	set SetOf[T]
+}
+
+// emptyCollection initializes an empty synchronized set that guards an empty set of the
+// same kind.
+func (c *SyncSet[T]) emptyCollection(bounded bool) CollectionOf[T] {
+	return SynchronizedSet(c.empty(bounded).(SetOf[T]))
+}
+
+// Iterator gets an iterator over a snapshot of the elements of the set. Removing an element
+// through the iterator removes it from the set.
+func (s *SyncSet[T]) Iterator() Iterator[T] {
+	return newSnapshotIterator(s.ToArray(), 0, func(value T) {
+		s.Remove(value)
+	})
+}
+
+// unsharedSet gets a copy of the supplied set if it is guarded by a lock, otherwise the set
+// itself.
+func unsharedSet[T any](other SetOf[T]) SetOf[T] {
+	return unshared[T](other).(SetOf[T])
+}
+
+// Difference gets a set of the elements in this set that are not in the other set.
+func (s *SyncSet[T]) Difference(other SetOf[T]) SetOf[T] {
+	other = unsharedSet(other)
+	s.rlock()
+	defer s.runlock()
+	return s.set.Difference(other)
+}
+
+// Intersection gets a set of the elements in both this set and the other set.
+func (s *SyncSet[T]) Intersection(other SetOf[T]) SetOf[T] {
+	other = unsharedSet(other)
+	s.rlock()
+	defer s.runlock()
+	return s.set.Intersection(other)
+}
+
+// IsSubsetOf checks if every element in this set is also in the other set.
+func (s *SyncSet[T]) IsSubsetOf(other SetOf[T]) bool {
+	other = unsharedSet(other)
+	s.rlock()
+	defer s.runlock()
+	return s.set.IsSubsetOf(other)
+}
+
+// IsSupersetOf checks if every element in the other set is also in this set.
+func (s *SyncSet[T]) IsSupersetOf(other SetOf[T]) bool {
+	other = unsharedSet(other)
+	s.rlock()
+	defer s.runlock()
+	return s.set.IsSupersetOf(other)
+}
+
+// SymmetricDifference gets a set of the elements in exactly one of this set and the other set.
+func (s *SyncSet[T]) SymmetricDifference(other SetOf[T]) SetOf[T] {
+	other = unsharedSet(other)
+	s.rlock()
+	defer s.runlock()
+	return s.set.SymmetricDifference(other)
+}
+
+// Union gets a set of the elements in either this set or the other set.
+func (s *SyncSet[T]) Union(other SetOf[T]) SetOf[T] {
+	other = unsharedSet(other)
+	s.rlock()
+	defer s.runlock()
+	return s.set.Union(other)
+}
+
+// Do calls the supplied function with the underlying set while holding the write lock, so that
+// compound operations are applied atomically. The function must not retain the set or call
+// methods of the SyncSet.
+func (s *SyncSet[T]) Do(action func(SetOf[T])) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	action(s.set)
+}
+
+// snapshotIterator is a list iterator over a copy of the elements of a collection. It can only
+// remove elements, and only if it was given a function that removes a value from the collection.
+type snapshotIterator[T any] struct {
+	values []T
+	cursor int
+	last   int
+	remove func(T)
+}
+
+func (i *snapshotIterator[T]) HasNext() bool {
+	return i.cursor < len(i.values)
+}
+
+func (i *snapshotIterator[T]) Next() (T, error) {
+	if !i.HasNext() {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	i.last = i.cursor
+	i.cursor++
+	return i.values[i.last], nil
+}
+
+func (i *snapshotIterator[T]) Remove() error {
+	if i.remove == nil {
+		return ErrUnsupportedOperation
+	}
+	if i.last < 0 {
+		return ErrIllegalState
+	}
+	i.remove(i.values[i.last])
+	i.last = -1
+	return nil
+}
+
+func (i *snapshotIterator[T]) HasPrevious() bool {
+	return i.cursor > 0
+}
+
+func (i *snapshotIterator[T]) Previous() (T, error) {
+	if !i.HasPrevious() {
+		var zero T
+		return zero, ErrNoSuchElement
+	}
+	i.cursor--
+	i.last = i.cursor
+	return i.values[i.cursor], nil
+}
+
+func (i *snapshotIterator[T]) NextIndex() int {
+	return i.cursor
+}
+
+func (i *snapshotIterator[T]) PreviousIndex() int {
+	return i.cursor - 1
+}
+
+func (i *snapshotIterator[T]) Set(value T) error {
+	return ErrUnsupportedOperation
+}
+
+func (i *snapshotIterator[T]) Add(value T) error {
+	return ErrUnsupportedOperation
+}
+
+// newSnapshotIterator initializes an iterator over the supplied values with the cursor at the
+// specified index. The iterator removes elements with the supplied function, or reports that
+// removal is not supported if it is nil.
+func newSnapshotIterator[T any](values []T, index int, remove func(T)) *snapshotIterator[T] {
+	return &snapshotIterator[T]{values: values, cursor: index, last: -1, remove: remove}
+}
+
+// newSyncList initializes a list guarded by the supplied mutex.
+func newSyncList[T any](list ListOf[T], mutex *sync.RWMutex) *SyncList[T] {
+	return &SyncList[T]{
+		synchronizedCollection: newSynchronizedCollection[T](list, mutex),
+		list:                   list,
+	}
+}
+
+// SynchronizedCollection wraps a collection so that it may be shared between goroutines.
+// The collection must not be accessed other than through the returned wrapper.
+func SynchronizedCollection[T any](collection CollectionOf[T]) *SyncCollection[T] {
+	return &SyncCollection[T]{
+		synchronizedCollection: newSynchronizedCollection[T](collection, &sync.RWMutex{}),
+	}
+}
+
+// Synchronized wraps a list so that it may be shared between goroutines.
+// The list must not be accessed other than through the returned wrapper.
+func Synchronized[T any](list ListOf[T]) *SyncList[T] {
+	return newSyncList(list, &sync.RWMutex{})
+}
+
+// SynchronizedQueue wraps a queue, e.g. one created by NewLinkedQueueOf, so that it may be shared
+// between goroutines. The queue must not be accessed other than through the returned wrapper.
+func SynchronizedQueue[T any](queue QueueOf[T]) *SyncQueue[T] {
+	return &SyncQueue[T]{
+		synchronizedCollection: newSynchronizedCollection[T](queue, &sync.RWMutex{}),
+		queue:                  queue,
+	}
+}
+
+// SynchronizedDeque wraps a deque so that it may be shared between goroutines.
+// The deque must not be accessed other than through the returned wrapper.
+func SynchronizedDeque[T any](deque DequeOf[T]) *SyncDeque[T] {
+	return &SyncDeque[T]{
+		synchronizedCollection: newSynchronizedCollection[T](deque, &sync.RWMutex{}),
+		deque:                  deque,
+	}
+}
+
+// SynchronizedStack wraps a stack so that it may be shared between goroutines.
+// The stack must not be accessed other than through the returned wrapper.
+func SynchronizedStack[T any](stack StackOf[T]) *SyncStack[T] {
+	return &SyncStack[T]{
+		synchronizedCollection: newSynchronizedCollection[T](stack, &sync.RWMutex{}),
+		stack:                  stack,
+	}
+}
+
+// SynchronizedSet wraps a set so that it may be shared between goroutines.
+// The set must not be accessed other than through the returned wrapper.
+func SynchronizedSet[T any](set SetOf[T]) *SyncSet[T] {
+	return &SyncSet[T]{
+		synchronizedCollection: newSynchronizedCollection[T](set, &sync.RWMutex{}),
+		set:                    set,
+	}
+}
@@ -0,0 +1,217 @@
+package gollections_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+)
+
+// Test the synchronized wrappers as implementations of each interface.
+func TestSynchronized(t *testing.T) {
+	testCollection(t, gollections.SynchronizedCollection(gollections.NewLinkedCollectionOf[any]()))
+	testList(t, gollections.Synchronized(gollections.NewArrayListOf[any]()))
+	testQueue(t, gollections.SynchronizedQueue(gollections.NewLinkedQueueOf[any]()))
+	testDeque(t, gollections.SynchronizedDeque(gollections.NewArrayDequeOf[any]()))
+	testStack(t, gollections.SynchronizedStack(gollections.NewLinkedStackOf[any]()))
+	testSet(t, func(options ...gollections.Option[int]) gollections.SetOf[int] {
+		return gollections.SynchronizedSet(gollections.NewHashSetOf(options...))
+	})
+}
+
+// TestSynchronizedSnapshot tests that iterating a synchronized collection visits a snapshot that
+// is not affected by modifications made during iteration.
+func TestSynchronizedSnapshot(t *testing.T) {
+	list := gollections.Synchronized(gollections.NewLinkedListOf[int]())
+	list.Add(1, 2, 3)
+	var visited []int
+	for value := range list.All() {
+		visited = append(visited, value)
+		list.Add(value * 10)
+	}
+	if len(visited) != 3 {
+		t.Fatalf("expected 3 visited elements, got %v", visited)
+	}
+	expectList(t, list, 1, 2, 3, 10, 20, 30)
+
+	it := list.ListIterator()
+	if _, err := it.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if err := it.Remove(); !errors.Is(err, gollections.ErrUnsupportedOperation) {
+		t.Fatalf("expected unsupported operation, got %v", err)
+	}
+	if err := it.Set(0); !errors.Is(err, gollections.ErrUnsupportedOperation) {
+		t.Fatalf("expected unsupported operation, got %v", err)
+	}
+
+	// bulk operations with the list itself must not deadlock
+	list.AddAll(list)
+	list.RetainAll(list)
+	if list.Size() != 12 {
+		t.Fatalf("expected size 12, got %d", list.Size())
+	}
+	set := gollections.SynchronizedSet(gollections.NewHashSetOf[int]())
+	set.Add(1, 2)
+	if !set.IsSubsetOf(set) || set.Union(set).Size() != 2 {
+		t.Fatal("unexpected result of set operation with itself")
+	}
+}
+
+// TestSynchronizedDo tests that compound operations made through Do are atomic.
+func TestSynchronizedDo(t *testing.T) {
+	list := gollections.Synchronized(gollections.NewLinkedListOf[int]())
+	list.Add(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				list.Do(func(l gollections.ListOf[int]) {
+					value, err := l.Get(0)
+					if err != nil {
+						panic(err)
+					}
+					if err := l.Set(0, value+1); err != nil {
+						panic(err)
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	expectList(t, list, 800)
+}
+
+// TestSynchronizedQueue tests that goroutines can share a linked queue.
+func TestSynchronizedQueue(t *testing.T) {
+	queue := gollections.SynchronizedQueue(gollections.NewLinkedQueueOf[int]())
+	const producers, count = 4, 1000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				queue.Add(p*count + i)
+			}
+		}(p)
+	}
+	seen := make([]bool, producers*count)
+	var mutex sync.Mutex
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				for range queue.All() {
+				}
+				value, err := queue.PopFirst()
+				if errors.Is(err, gollections.ErrNoSuchElement) {
+					i--
+					continue
+				}
+				mutex.Lock()
+				seen[value] = true
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	for value, ok := range seen {
+		if !ok {
+			t.Fatalf("value %d was not consumed", value)
+		}
+	}
+	if !queue.IsEmpty() {
+		t.Fatalf("expected empty queue, got %v", queue.ToArray())
+	}
+}
+
+// TestSynchronizedIndexedAccess tests that concurrent reads by index of a linked list and a view
+// of it are safe.
+func TestSynchronizedIndexedAccess(t *testing.T) {
+	list := gollections.Synchronized(gollections.NewLinkedListOf[int]())
+	for i := 0; i < 100; i++ {
+		list.Add(i)
+	}
+	view, err := list.SubList(10, 90)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 80; i++ {
+				index := (i * (g + 1)) % 80
+				value, err := list.Get(index + 10)
+				if err != nil || value != index+10 {
+					t.Errorf("expected %d, got %d (%v)", index+10, value, err)
+					return
+				}
+				if view.IndexOf(index+10) != index {
+					t.Errorf("expected index %d of %d in view", index, index+10)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// TestSynchronizedLinkedListView tests that synchronized wrappers of a view of a linked list may
+// be read and written concurrently. Run with the race detector.
+func TestSynchronizedLinkedListView(t *testing.T) {
+	newView := func() gollections.ListOf[int] {
+		list := gollections.NewLinkedListOf[int]()
+		for i := 0; i < 100; i++ {
+			list.Add(i)
+		}
+		view, err := list.SubList(0, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return view
+	}
+	list := gollections.Synchronized(newView())
+	collection := gollections.SynchronizedCollection(newView())
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				index := (i*(g+1) + g) % 100
+				if g == 0 {
+					list.Set(index, index)
+					collection.Add(100 + i)
+					continue
+				}
+				if value, err := list.Get(index); err != nil || value != index {
+					t.Errorf("expected %d, got %d, err: %v", index, value, err)
+					return
+				}
+				if !collection.Contains(index) {
+					t.Errorf("expected collection to contain %d", index)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if size := collection.Size(); size != 200 {
+		t.Fatalf("expected size 200, got %d", size)
+	}
+}
+
+// TestSynchronizedFilter tests that functional operations keep the synchronized wrapper.
+func TestSynchronizedFilter(t *testing.T) {
+	list := gollections.Synchronized(gollections.NewArrayListOf[int]())
+	list.Add(1, 2, 3, 4)
+	even, _ := gollections.Filter(list, isEven)
+	expectList(t, even, 2, 4)
+}
@@ -0,0 +1,150 @@
+package tree
+
+import (
+	"iter"
+
+	"github.com/bsladewski/gollections"
+)
+
+// A treeMap is a sorted map backed by a red-black tree.
+type treeMap[K, V any] struct {
+	tree rbTree[K, V]
+}
+
+// entry gets the key/value pair held by a node, or an error if the node does not exist.
+func entry[K, V any](n *node[K, V]) (Entry[K, V], error) {
+	if n == nil {
+		return Entry[K, V]{}, gollections.ErrNoSuchElement
+	}
+	return Entry[K, V]{Key: n.key, Value: n.value}, nil
+}
+
+// empty initializes a new map with the same comparator as this map.
+func (m *treeMap[K, V]) empty() *treeMap[K, V] {
+	return &treeMap[K, V]{tree: rbTree[K, V]{compare: m.tree.compare}}
+}
+
+// walk creates a sequence over the entries of the map in the specified direction.
+func (m *treeMap[K, V]) walk(descending bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.nodes(descending, func(n *node[K, V]) bool {
+			return yield(n.key, n.value)
+		})
+	}
+}
+
+func (m *treeMap[K, V]) All() iter.Seq2[K, V] {
+	return m.walk(false)
+}
+
+func (m *treeMap[K, V]) Ceiling(key K) (Entry[K, V], error) {
+	return entry(m.tree.ceiling(key))
+}
+
+func (m *treeMap[K, V]) Clear() {
+	m.tree.clear()
+}
+
+func (m *treeMap[K, V]) ContainsKey(key K) bool {
+	return m.tree.get(key) != nil
+}
+
+func (m *treeMap[K, V]) Descending() iter.Seq2[K, V] {
+	return m.walk(true)
+}
+
+func (m *treeMap[K, V]) First() (Entry[K, V], error) {
+	return entry(m.tree.first())
+}
+
+func (m *treeMap[K, V]) Floor(key K) (Entry[K, V], error) {
+	return entry(m.tree.floor(key))
+}
+
+func (m *treeMap[K, V]) Get(key K) (V, error) {
+	n := m.tree.get(key)
+	if n == nil {
+		var zero V
+		return zero, gollections.ErrNoSuchElement
+	}
+	return n.value, nil
+}
+
+func (m *treeMap[K, V]) HeadMap(to K) SortedMapOf[K, V] {
+	result := m.empty()
+	for n := m.tree.first(); n != nil && m.tree.compare(n.key, to) < 0; n = successor(n) {
+		result.tree.put(n.key, n.value)
+	}
+	return result
+}
+
+func (m *treeMap[K, V]) Higher(key K) (Entry[K, V], error) {
+	return entry(m.tree.higher(key))
+}
+
+func (m *treeMap[K, V]) IsEmpty() bool {
+	return m.tree.size == 0
+}
+
+func (m *treeMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (m *treeMap[K, V]) Last() (Entry[K, V], error) {
+	return entry(m.tree.last())
+}
+
+func (m *treeMap[K, V]) Lower(key K) (Entry[K, V], error) {
+	return entry(m.tree.lower(key))
+}
+
+func (m *treeMap[K, V]) Put(key K, value V) {
+	m.tree.put(key, value)
+}
+
+func (m *treeMap[K, V]) Remove(key K) {
+	if n := m.tree.get(key); n != nil {
+		m.tree.delete(n)
+	}
+}
+
+func (m *treeMap[K, V]) Size() int {
+	return m.tree.size
+}
+
+func (m *treeMap[K, V]) SubMap(from, to K) SortedMapOf[K, V] {
+	result := m.empty()
+	for n := m.tree.ceiling(from); n != nil && m.tree.compare(n.key, to) < 0; n = successor(n) {
+		result.tree.put(n.key, n.value)
+	}
+	return result
+}
+
+func (m *treeMap[K, V]) TailMap(from K) SortedMapOf[K, V] {
+	result := m.empty()
+	for n := m.tree.ceiling(from); n != nil; n = successor(n) {
+		result.tree.put(n.key, n.value)
+	}
+	return result
+}
+
+func (m *treeMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// NewTreeMapOf initializes a sorted map that orders its entries with the supplied comparator.
+func NewTreeMapOf[K, V any](compare gollections.Comparator[K]) SortedMapOf[K, V] {
+	return &treeMap[K, V]{tree: rbTree[K, V]{compare: compare}}
+}
@@ -0,0 +1,104 @@
+package tree_test
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+	"github.com/bsladewski/gollections/tree"
+)
+
+// TestTreeMap tests all exported functionality of a tree backed sorted map.
+func TestTreeMap(t *testing.T) {
+	m := tree.NewTreeMapOf[string, int](cmp.Compare[string])
+	// get, first, contains key; empty map
+	if got, err := m.Get("a"); got != 0 || err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected zero value and no such element error, got %d, err: %v", got, err)
+	}
+	if _, err := m.First(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if m.ContainsKey("a") || !m.IsEmpty() {
+		t.Fatal("expected empty map")
+	}
+	// put, get
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("e", 5)
+	m.Put("b", 2)
+	m.Put("a", 10)
+	if size := m.Size(); size != 4 {
+		t.Fatalf("expected size 4, got %d", size)
+	}
+	if got, err := m.Get("a"); err != nil || got != 10 {
+		t.Fatalf("expected 10, got %d, err: %v", got, err)
+	}
+	// navigation
+	if got, err := m.Floor("d"); err != nil || got != (tree.Entry[string, int]{Key: "c", Value: 3}) {
+		t.Fatalf("expected c=3, got %v, err: %v", got, err)
+	}
+	if got, err := m.Ceiling("d"); err != nil || got.Key != "e" {
+		t.Fatalf("expected e, got %v, err: %v", got, err)
+	}
+	if got, err := m.Lower("b"); err != nil || got.Key != "a" {
+		t.Fatalf("expected a, got %v, err: %v", got, err)
+	}
+	if got, err := m.Higher("e"); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v, err: %v", got, err)
+	}
+	if got, err := m.Last(); err != nil || got.Key != "e" {
+		t.Fatalf("expected e, got %v, err: %v", got, err)
+	}
+	// keys, values, descending
+	keys := []string{}
+	for key := range m.Keys() {
+		keys = append(keys, key)
+	}
+	if expected := []string{"a", "b", "c", "e"}; !reflect.DeepEqual(expected, keys) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+	values := []int{}
+	for value := range m.Values() {
+		values = append(values, value)
+	}
+	if expected := []int{10, 2, 3, 5}; !reflect.DeepEqual(expected, values) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+	keys = []string{}
+	for key, value := range m.Descending() {
+		keys = append(keys, key)
+		if value == 3 {
+			break
+		}
+	}
+	if expected := []string{"e", "c"}; !reflect.DeepEqual(expected, keys) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+	// sub map, head map, tail map
+	if size := m.SubMap("b", "e").Size(); size != 2 {
+		t.Fatalf("expected size 2, got %d", size)
+	}
+	if got, err := m.HeadMap("c").Last(); err != nil || got.Key != "b" {
+		t.Fatalf("expected b, got %v, err: %v", got, err)
+	}
+	if got, err := m.TailMap("c").First(); err != nil || got.Key != "c" {
+		t.Fatalf("expected c, got %v, err: %v", got, err)
+	}
+	// sub maps are copies
+	head := m.HeadMap("c")
+	head.Put("a", 0)
+	if got, _ := m.Get("a"); got == 0 {
+		t.Fatal("expected head map to be a copy")
+	}
+	// remove, clear
+	m.Remove("c")
+	m.Remove("z")
+	if m.ContainsKey("c") || m.Size() != 3 {
+		t.Fatal("expected c to be removed")
+	}
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Fatal("expected empty map")
+	}
+}
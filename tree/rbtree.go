@@ -0,0 +1,520 @@
+package tree
+
+import "github.com/bsladewski/gollections"
+
+// color is the color of a node in a red-black tree.
+type color bool
+
+const (
+	red   color = false
+	black color = true
+)
+
+// node is a single entry in a red-black tree.
+type node[K, V any] struct {
+	key    K
+	value  V
+	left   *node[K, V]
+	right  *node[K, V]
+	parent *node[K, V]
+	color  color
+}
+
+// rbTree is a red-black tree, a binary search tree that keeps itself balanced so that lookups,
+// insertions and deletions are O(log n).
+type rbTree[K, V any] struct {
+	root     *node[K, V]
+	compare  gollections.Comparator[K]
+	size     int
+	modCount int
+}
+
+// colorOf gets the color of a node. Missing nodes are black.
+func colorOf[K, V any](n *node[K, V]) color {
+	if n == nil {
+		return black
+	}
+	return n.color
+}
+
+// setColor sets the color of a node if it exists.
+func setColor[K, V any](n *node[K, V], c color) {
+	if n != nil {
+		n.color = c
+	}
+}
+
+// parentOf gets the parent of a node if it exists.
+func parentOf[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	return n.parent
+}
+
+// leftOf gets the left child of a node if it exists.
+func leftOf[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	return n.left
+}
+
+// rightOf gets the right child of a node if it exists.
+func rightOf[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	return n.right
+}
+
+// successor gets the node that follows the supplied node in key order.
+func successor[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	if n.right != nil {
+		p := n.right
+		for p.left != nil {
+			p = p.left
+		}
+		return p
+	}
+	p := n.parent
+	for p != nil && n == p.right {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// predecessor gets the node that precedes the supplied node in key order.
+func predecessor[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	if n.left != nil {
+		p := n.left
+		for p.right != nil {
+			p = p.right
+		}
+		return p
+	}
+	p := n.parent
+	for p != nil && n == p.left {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// clear removes all nodes from the tree.
+func (t *rbTree[K, V]) clear() {
+	t.root = nil
+	t.size = 0
+	t.modCount++
+}
+
+// first gets the node with the smallest key.
+func (t *rbTree[K, V]) first() *node[K, V] {
+	p := t.root
+	for p != nil && p.left != nil {
+		p = p.left
+	}
+	return p
+}
+
+// last gets the node with the largest key.
+func (t *rbTree[K, V]) last() *node[K, V] {
+	p := t.root
+	for p != nil && p.right != nil {
+		p = p.right
+	}
+	return p
+}
+
+// get finds the node with the supplied key. Returns nil if no such node exists.
+func (t *rbTree[K, V]) get(key K) *node[K, V] {
+	p := t.root
+	for p != nil {
+		c := t.compare(key, p.key)
+		switch {
+		case c < 0:
+			p = p.left
+		case c > 0:
+			p = p.right
+		default:
+			return p
+		}
+	}
+	return nil
+}
+
+// ceiling finds the node with the smallest key greater than or equal to the supplied key.
+func (t *rbTree[K, V]) ceiling(key K) *node[K, V] {
+	var best *node[K, V]
+	p := t.root
+	for p != nil {
+		c := t.compare(key, p.key)
+		switch {
+		case c < 0:
+			best = p
+			p = p.left
+		case c > 0:
+			p = p.right
+		default:
+			return p
+		}
+	}
+	return best
+}
+
+// floor finds the node with the largest key less than or equal to the supplied key.
+func (t *rbTree[K, V]) floor(key K) *node[K, V] {
+	var best *node[K, V]
+	p := t.root
+	for p != nil {
+		c := t.compare(key, p.key)
+		switch {
+		case c > 0:
+			best = p
+			p = p.right
+		case c < 0:
+			p = p.left
+		default:
+			return p
+		}
+	}
+	return best
+}
+
+// higher finds the node with the smallest key strictly greater than the supplied key.
+func (t *rbTree[K, V]) higher(key K) *node[K, V] {
+	var best *node[K, V]
+	p := t.root
+	for p != nil {
+		if t.compare(key, p.key) < 0 {
+			best = p
+			p = p.left
+		} else {
+			p = p.right
+		}
+	}
+	return best
+}
+
+// lower finds the node with the largest key strictly less than the supplied key.
+func (t *rbTree[K, V]) lower(key K) *node[K, V] {
+	var best *node[K, V]
+	p := t.root
+	for p != nil {
+		if t.compare(key, p.key) > 0 {
+			best = p
+			p = p.right
+		} else {
+			p = p.left
+		}
+	}
+	return best
+}
+
+// put adds a new node or updates the value of an existing node.
+// Returns true if a new node was added.
+func (t *rbTree[K, V]) put(key K, value V) bool {
+	if t.root == nil {
+		t.root = &node[K, V]{key: key, value: value, color: black}
+		t.size = 1
+		t.modCount++
+		return true
+	}
+	var parent *node[K, V]
+	var c int
+	p := t.root
+	for p != nil {
+		parent = p
+		c = t.compare(key, p.key)
+		switch {
+		case c < 0:
+			p = p.left
+		case c > 0:
+			p = p.right
+		default:
+			p.value = value
+			return false
+		}
+	}
+	e := &node[K, V]{key: key, value: value, parent: parent}
+	if c < 0 {
+		parent.left = e
+	} else {
+		parent.right = e
+	}
+	t.fixAfterInsertion(e)
+	t.size++
+	t.modCount++
+	return true
+}
+
+// delete removes the supplied node from the tree.
+// If the node has two children its successor is moved into it, and the successor node is
+// unlinked instead.
+func (t *rbTree[K, V]) delete(p *node[K, V]) {
+	t.modCount++
+	t.size--
+	if p.left != nil && p.right != nil {
+		s := successor(p)
+		p.key = s.key
+		p.value = s.value
+		p = s
+	}
+	replacement := p.left
+	if replacement == nil {
+		replacement = p.right
+	}
+	switch {
+	case replacement != nil:
+		replacement.parent = p.parent
+		switch {
+		case p.parent == nil:
+			t.root = replacement
+		case p == p.parent.left:
+			p.parent.left = replacement
+		default:
+			p.parent.right = replacement
+		}
+		p.left, p.right, p.parent = nil, nil, nil
+		if p.color == black {
+			t.fixAfterDeletion(replacement)
+		}
+	case p.parent == nil:
+		t.root = nil
+	default:
+		if p.color == black {
+			t.fixAfterDeletion(p)
+		}
+		if p.parent != nil {
+			if p == p.parent.left {
+				p.parent.left = nil
+			} else if p == p.parent.right {
+				p.parent.right = nil
+			}
+			p.parent = nil
+		}
+	}
+}
+
+// rotateLeft rotates the subtree rooted at the supplied node to the left.
+func (t *rbTree[K, V]) rotateLeft(p *node[K, V]) {
+	if p == nil {
+		return
+	}
+	r := p.right
+	p.right = r.left
+	if r.left != nil {
+		r.left.parent = p
+	}
+	r.parent = p.parent
+	switch {
+	case p.parent == nil:
+		t.root = r
+	case p.parent.left == p:
+		p.parent.left = r
+	default:
+		p.parent.right = r
+	}
+	r.left = p
+	p.parent = r
+}
+
+// rotateRight rotates the subtree rooted at the supplied node to the right.
+func (t *rbTree[K, V]) rotateRight(p *node[K, V]) {
+	if p == nil {
+		return
+	}
+	l := p.left
+	p.left = l.right
+	if l.right != nil {
+		l.right.parent = p
+	}
+	l.parent = p.parent
+	switch {
+	case p.parent == nil:
+		t.root = l
+	case p.parent.right == p:
+		p.parent.right = l
+	default:
+		p.parent.left = l
+	}
+	l.right = p
+	p.parent = l
+}
+
+// fixAfterInsertion restores the red-black properties after a node is added.
+func (t *rbTree[K, V]) fixAfterInsertion(x *node[K, V]) {
+	x.color = red
+	for x != nil && x != t.root && x.parent.color == red {
+		if parentOf(x) == leftOf(parentOf(parentOf(x))) {
+			y := rightOf(parentOf(parentOf(x)))
+			if colorOf(y) == red {
+				setColor(parentOf(x), black)
+				setColor(y, black)
+				setColor(parentOf(parentOf(x)), red)
+				x = parentOf(parentOf(x))
+			} else {
+				if x == rightOf(parentOf(x)) {
+					x = parentOf(x)
+					t.rotateLeft(x)
+				}
+				setColor(parentOf(x), black)
+				setColor(parentOf(parentOf(x)), red)
+				t.rotateRight(parentOf(parentOf(x)))
+			}
+		} else {
+			y := leftOf(parentOf(parentOf(x)))
+			if colorOf(y) == red {
+				setColor(parentOf(x), black)
+				setColor(y, black)
+				setColor(parentOf(parentOf(x)), red)
+				x = parentOf(parentOf(x))
+			} else {
+				if x == leftOf(parentOf(x)) {
+					x = parentOf(x)
+					t.rotateRight(x)
+				}
+				setColor(parentOf(x), black)
+				setColor(parentOf(parentOf(x)), red)
+				t.rotateLeft(parentOf(parentOf(x)))
+			}
+		}
+	}
+	t.root.color = black
+}
+
+// fixAfterDeletion restores the red-black properties after a node is removed.
+func (t *rbTree[K, V]) fixAfterDeletion(x *node[K, V]) {
+	for x != t.root && colorOf(x) == black {
+		if x == leftOf(parentOf(x)) {
+			sib := rightOf(parentOf(x))
+			if colorOf(sib) == red {
+				setColor(sib, black)
+				setColor(parentOf(x), red)
+				t.rotateLeft(parentOf(x))
+				sib = rightOf(parentOf(x))
+			}
+			if colorOf(leftOf(sib)) == black && colorOf(rightOf(sib)) == black {
+				setColor(sib, red)
+				x = parentOf(x)
+			} else {
+				if colorOf(rightOf(sib)) == black {
+					setColor(leftOf(sib), black)
+					setColor(sib, red)
+					t.rotateRight(sib)
+					sib = rightOf(parentOf(x))
+				}
+				setColor(sib, colorOf(parentOf(x)))
+				setColor(parentOf(x), black)
+				setColor(rightOf(sib), black)
+				t.rotateLeft(parentOf(x))
+				x = t.root
+			}
+		} else {
+			sib := leftOf(parentOf(x))
+			if colorOf(sib) == red {
+				setColor(sib, black)
+				setColor(parentOf(x), red)
+				t.rotateRight(parentOf(x))
+				sib = leftOf(parentOf(x))
+			}
+			if colorOf(rightOf(sib)) == black && colorOf(leftOf(sib)) == black {
+				setColor(sib, red)
+				x = parentOf(x)
+			} else {
+				if colorOf(leftOf(sib)) == black {
+					setColor(rightOf(sib), black)
+					setColor(sib, red)
+					t.rotateLeft(sib)
+					sib = leftOf(parentOf(x))
+				}
+				setColor(sib, colorOf(parentOf(x)))
+				setColor(parentOf(x), black)
+				setColor(leftOf(sib), black)
+				t.rotateRight(parentOf(x))
+				x = t.root
+			}
+		}
+	}
+	setColor(x, black)
+}
+
+// treeIterator walks the nodes of a tree in ascending or descending key order.
+type treeIterator[K, V any] struct {
+	tree       *rbTree[K, V]
+	next       *node[K, V]
+	last       *node[K, V]
+	descending bool
+	expected   int
+}
+
+// newTreeIterator initializes an iterator starting at the first or last node of the tree.
+func newTreeIterator[K, V any](t *rbTree[K, V], descending bool) *treeIterator[K, V] {
+	next := t.first()
+	if descending {
+		next = t.last()
+	}
+	return &treeIterator[K, V]{tree: t, next: next, descending: descending, expected: t.modCount}
+}
+
+// hasNext checks if the iteration has more nodes.
+func (i *treeIterator[K, V]) hasNext() bool {
+	return i.next != nil
+}
+
+// nextNode gets the next node in the iteration.
+func (i *treeIterator[K, V]) nextNode() (*node[K, V], error) {
+	if i.tree.modCount != i.expected {
+		return nil, gollections.ErrConcurrentModification
+	}
+	if i.next == nil {
+		return nil, gollections.ErrNoSuchElement
+	}
+	i.last = i.next
+	if i.descending {
+		i.next = predecessor(i.next)
+	} else {
+		i.next = successor(i.next)
+	}
+	return i.last, nil
+}
+
+// remove deletes the node last returned by the iterator.
+func (i *treeIterator[K, V]) remove() error {
+	if i.tree.modCount != i.expected {
+		return gollections.ErrConcurrentModification
+	}
+	if i.last == nil {
+		return gollections.ErrIllegalState
+	}
+	// deleting a node with two children moves its successor into it
+	if !i.descending && i.last.left != nil && i.last.right != nil {
+		i.next = i.last
+	}
+	i.tree.delete(i.last)
+	i.last = nil
+	i.expected = i.tree.modCount
+	return nil
+}
+
+// nodes walks the tree from first to last, or last to first, calling yield for each node.
+// Panics if the tree is modified during the walk.
+func (t *rbTree[K, V]) nodes(descending bool, yield func(*node[K, V]) bool) {
+	it := newTreeIterator(t, descending)
+	for it.hasNext() {
+		n, err := it.nextNode()
+		if err != nil {
+			panic(err)
+		}
+		if !yield(n) {
+			return
+		}
+	}
+}
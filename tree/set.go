@@ -0,0 +1,253 @@
+package tree
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+
+	"github.com/bsladewski/gollections"
+)
+
+// A treeSet is a sorted set backed by a red-black tree.
+type treeSet[T any] struct {
+	tree rbTree[T, struct{}]
+}
+
+// key gets the element held by a node, or an error if the node does not exist.
+func key[T any](n *node[T, struct{}]) (T, error) {
+	if n == nil {
+		var zero T
+		return zero, gollections.ErrNoSuchElement
+	}
+	return n.key, nil
+}
+
+// empty initializes a new set with the same comparator as this set.
+func (s *treeSet[T]) empty() *treeSet[T] {
+	return &treeSet[T]{tree: rbTree[T, struct{}]{compare: s.tree.compare}}
+}
+
+// Add adds new elements to the set.
+// Values that are already in the set are ignored.
+func (s *treeSet[T]) Add(values ...T) {
+	for _, value := range values {
+		s.tree.put(value, struct{}{})
+	}
+}
+
+func (s *treeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.tree.nodes(false, func(n *node[T, struct{}]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+func (s *treeSet[T]) Clear() {
+	s.tree.clear()
+}
+
+func (s *treeSet[T]) Contains(values ...T) bool {
+	for _, value := range values {
+		if s.tree.get(value) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *treeSet[T]) IsEmpty() bool {
+	return s.tree.size == 0
+}
+
+func (s *treeSet[T]) Iterator() gollections.Iterator[T] {
+	return &setIterator[T]{newTreeIterator(&s.tree, false)}
+}
+
+func (s *treeSet[T]) Remove(values ...T) {
+	for _, value := range values {
+		if n := s.tree.get(value); n != nil {
+			s.tree.delete(n)
+		}
+	}
+}
+
+func (s *treeSet[T]) Size() int {
+	return s.tree.size
+}
+
+func (s *treeSet[T]) SliceCopy(ptrToSlice interface{}) error {
+	value := reflect.ValueOf(ptrToSlice)
+	if value.Kind() != reflect.Ptr {
+		return fmt.Errorf("supplied value of type %v is not a pointer", value.Type())
+	}
+	value = value.Elem()
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("supplied value of type %v is not a pointer to a slice", value.Type())
+	}
+	value.Set(reflect.MakeSlice(value.Type(), s.tree.size, s.tree.size))
+	index := 0
+	for v := range s.All() {
+		setValue := reflect.ValueOf(v)
+		if value.Index(index).Kind() != setValue.Kind() {
+			return fmt.Errorf("cannot assign type %v to element of type %v", setValue.Kind(),
+				value.Index(index).Kind())
+		}
+		value.Index(index).Set(setValue)
+		index++
+	}
+	return nil
+}
+
+func (s *treeSet[T]) ToArray() []T {
+	array := make([]T, 0, s.tree.size)
+	for value := range s.All() {
+		array = append(array, value)
+	}
+	return array
+}
+
+func (s *treeSet[T]) Difference(other gollections.SetOf[T]) gollections.SetOf[T] {
+	result := s.empty()
+	for value := range s.All() {
+		if !other.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+func (s *treeSet[T]) Intersection(other gollections.SetOf[T]) gollections.SetOf[T] {
+	result := s.empty()
+	for value := range s.All() {
+		if other.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+func (s *treeSet[T]) IsSubsetOf(other gollections.SetOf[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	for value := range s.All() {
+		if !other.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *treeSet[T]) IsSupersetOf(other gollections.SetOf[T]) bool {
+	for value := range other.All() {
+		if !s.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *treeSet[T]) SymmetricDifference(other gollections.SetOf[T]) gollections.SetOf[T] {
+	result := s.Difference(other)
+	for value := range other.All() {
+		if !s.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+func (s *treeSet[T]) Union(other gollections.SetOf[T]) gollections.SetOf[T] {
+	result := s.empty()
+	for value := range s.All() {
+		result.Add(value)
+	}
+	for value := range other.All() {
+		result.Add(value)
+	}
+	return result
+}
+
+func (s *treeSet[T]) Ceiling(value T) (T, error) {
+	return key(s.tree.ceiling(value))
+}
+
+func (s *treeSet[T]) Descending() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.tree.nodes(true, func(n *node[T, struct{}]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+func (s *treeSet[T]) First() (T, error) {
+	return key(s.tree.first())
+}
+
+func (s *treeSet[T]) Floor(value T) (T, error) {
+	return key(s.tree.floor(value))
+}
+
+func (s *treeSet[T]) HeadSet(to T) SortedSetOf[T] {
+	result := s.empty()
+	for n := s.tree.first(); n != nil && s.tree.compare(n.key, to) < 0; n = successor(n) {
+		result.tree.put(n.key, struct{}{})
+	}
+	return result
+}
+
+func (s *treeSet[T]) Higher(value T) (T, error) {
+	return key(s.tree.higher(value))
+}
+
+func (s *treeSet[T]) Last() (T, error) {
+	return key(s.tree.last())
+}
+
+func (s *treeSet[T]) Lower(value T) (T, error) {
+	return key(s.tree.lower(value))
+}
+
+func (s *treeSet[T]) SubSet(from, to T) SortedSetOf[T] {
+	result := s.empty()
+	for n := s.tree.ceiling(from); n != nil && s.tree.compare(n.key, to) < 0; n = successor(n) {
+		result.tree.put(n.key, struct{}{})
+	}
+	return result
+}
+
+func (s *treeSet[T]) TailSet(from T) SortedSetOf[T] {
+	result := s.empty()
+	for n := s.tree.ceiling(from); n != nil; n = successor(n) {
+		result.tree.put(n.key, struct{}{})
+	}
+	return result
+}
+
+// setIterator exposes the elements visited by a tree iterator.
+type setIterator[T any] struct {
+	iterator *treeIterator[T, struct{}]
+}
+
+func (i *setIterator[T]) HasNext() bool {
+	return i.iterator.hasNext()
+}
+
+func (i *setIterator[T]) Next() (T, error) {
+	n, err := i.iterator.nextNode()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return n.key, nil
+}
+
+func (i *setIterator[T]) Remove() error {
+	return i.iterator.remove()
+}
+
+// NewTreeSetOf initializes a sorted set that orders its elements with the supplied comparator.
+func NewTreeSetOf[T any](compare gollections.Comparator[T]) SortedSetOf[T] {
+	return &treeSet[T]{tree: rbTree[T, struct{}]{compare: compare}}
+}
@@ -0,0 +1,209 @@
+package tree_test
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/bsladewski/gollections"
+	"github.com/bsladewski/gollections/tree"
+)
+
+// TestTreeSet tests all exported functionality of a tree backed sorted set.
+func TestTreeSet(t *testing.T) {
+	set := tree.NewTreeSetOf(cmp.Compare[int])
+	// first, last, floor, ceiling, lower, higher; empty set
+	if _, err := set.First(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if _, err := set.Last(); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	if _, err := set.Floor(1); err != gollections.ErrNoSuchElement {
+		t.Fatalf("expected no such element error, got %v", err)
+	}
+	set.Remove(1)
+	// add, to array
+	set.Add(50, 10, 40, 20, 30, 20)
+	expected := []int{10, 20, 30, 40, 50}
+	if got := set.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if size := set.Size(); size != 5 {
+		t.Fatalf("expected size 5, got %d", size)
+	}
+	// navigation
+	for _, test := range []struct {
+		name     string
+		call     func(int) (int, error)
+		value    int
+		expected int
+		err      error
+	}{
+		{"floor", set.Floor, 25, 20, nil},
+		{"floor", set.Floor, 20, 20, nil},
+		{"floor", set.Floor, 5, 0, gollections.ErrNoSuchElement},
+		{"ceiling", set.Ceiling, 25, 30, nil},
+		{"ceiling", set.Ceiling, 30, 30, nil},
+		{"ceiling", set.Ceiling, 55, 0, gollections.ErrNoSuchElement},
+		{"lower", set.Lower, 30, 20, nil},
+		{"lower", set.Lower, 10, 0, gollections.ErrNoSuchElement},
+		{"higher", set.Higher, 30, 40, nil},
+		{"higher", set.Higher, 50, 0, gollections.ErrNoSuchElement},
+	} {
+		if got, err := test.call(test.value); got != test.expected || err != test.err {
+			t.Fatalf("%s(%d): expected %d, %v, got %d, %v", test.name, test.value, test.expected,
+				test.err, got, err)
+		}
+	}
+	if got, err := set.First(); err != nil || got != 10 {
+		t.Fatalf("expected 10, got %d, err: %v", got, err)
+	}
+	if got, err := set.Last(); err != nil || got != 50 {
+		t.Fatalf("expected 50, got %d, err: %v", got, err)
+	}
+	// sub set, head set, tail set
+	if got := set.SubSet(15, 40).ToArray(); !reflect.DeepEqual([]int{20, 30}, got) {
+		t.Fatalf("expected [20 30], got %v", got)
+	}
+	if got := set.HeadSet(30).ToArray(); !reflect.DeepEqual([]int{10, 20}, got) {
+		t.Fatalf("expected [10 20], got %v", got)
+	}
+	if got := set.TailSet(30).ToArray(); !reflect.DeepEqual([]int{30, 40, 50}, got) {
+		t.Fatalf("expected [30 40 50], got %v", got)
+	}
+	// sub sets are copies
+	tail := set.TailSet(30)
+	tail.Add(60)
+	set.Remove(40)
+	if set.Contains(60) || !tail.Contains(40) {
+		t.Fatalf("expected tail set to be a copy, got %v and %v", set.ToArray(), tail.ToArray())
+	}
+	set.Add(40)
+	// descending
+	got := []int{}
+	for value := range set.Descending() {
+		got = append(got, value)
+	}
+	if expected := []int{50, 40, 30, 20, 10}; !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// set operations
+	other := gollections.NewHashSetOf[int]()
+	other.Add(5, 30, 60)
+	if got := set.Union(other).ToArray(); !reflect.DeepEqual([]int{5, 10, 20, 30, 40, 50, 60}, got) {
+		t.Fatalf("expected sorted union, got %v", got)
+	}
+	if got := set.Intersection(other).ToArray(); !reflect.DeepEqual([]int{30}, got) {
+		t.Fatalf("expected [30], got %v", got)
+	}
+	if got := set.SymmetricDifference(other).ToArray(); !reflect.DeepEqual(
+		[]int{5, 10, 20, 40, 50, 60}, got) {
+		t.Fatalf("expected sorted symmetric difference, got %v", got)
+	}
+	if !set.SubSet(20, 40).IsSubsetOf(set) || !set.IsSupersetOf(set.TailSet(40)) {
+		t.Fatal("expected sub set to be a subset")
+	}
+	// slice copy
+	copied := []int{}
+	if err := set.SliceCopy(&copied); err != nil || !reflect.DeepEqual(set.ToArray(), copied) {
+		t.Fatalf("expected %v, got %v, err: %v", set.ToArray(), copied, err)
+	}
+	if err := set.SliceCopy(&[]string{}); err == nil {
+		t.Fatal("expected error copying to a slice of another type")
+	}
+	// clear
+	set.Clear()
+	if !set.IsEmpty() {
+		t.Fatalf("expected empty set, got %v", set.ToArray())
+	}
+}
+
+// TestTreeSetComparator tests a sorted set with a custom comparator.
+func TestTreeSetComparator(t *testing.T) {
+	set := tree.NewTreeSetOf(func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	set.Add("a", "ccc", "bb", "dd")
+	expected := []string{"ccc", "bb", "a"}
+	if got := set.ToArray(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestTreeSetRandom compares a sorted set against a sorted slice under random additions and
+// removals, including removals made through an iterator.
+func TestTreeSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set := tree.NewTreeSetOf(cmp.Compare[int])
+	expected := map[int]bool{}
+	for i := 0; i < 5000; i++ {
+		value := r.Intn(500)
+		if r.Intn(3) == 0 {
+			set.Remove(value)
+			delete(expected, value)
+		} else {
+			set.Add(value)
+			expected[value] = true
+		}
+	}
+	it := set.Iterator()
+	for it.HasNext() {
+		value, err := it.Next()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if value%3 == 0 {
+			if err := it.Remove(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			delete(expected, value)
+		}
+	}
+	values := []int{}
+	for value := range expected {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	if got := set.ToArray(); !reflect.DeepEqual(values, got) {
+		t.Fatalf("expected %v, got %v", values, got)
+	}
+	if size := set.Size(); size != len(values) {
+		t.Fatalf("expected size %d, got %d", len(values), size)
+	}
+}
+
+// TestTreeSetConcurrentModification tests that iteration fails fast when the set is modified.
+func TestTreeSetConcurrentModification(t *testing.T) {
+	set := tree.NewTreeSetOf(cmp.Compare[int])
+	set.Add(1, 2, 3)
+	it := set.Iterator()
+	set.Add(4)
+	if _, err := it.Next(); err != gollections.ErrConcurrentModification {
+		t.Fatalf("expected concurrent modification error, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r != gollections.ErrConcurrentModification {
+			t.Fatalf("expected concurrent modification panic, got %v", r)
+		}
+	}()
+	for value := range set.All() {
+		set.Remove(value)
+	}
+}
+
+// TestTreeSetFilter tests that filtering a sorted set gets a sorted set.
+func TestTreeSetFilter(t *testing.T) {
+	set := tree.NewTreeSetOf(cmp.Compare[int])
+	set.Add(5, 2, 8, 1, 4)
+	evens, _ := gollections.Filter(set, func(value int) bool { return value%2 == 0 })
+	if got := evens.ToArray(); !reflect.DeepEqual([]int{2, 4, 8}, got) {
+		t.Fatalf("expected [2 4 8], got %v", got)
+	}
+	if first, err := evens.First(); err != nil || first != 2 {
+		t.Fatalf("expected 2, got %d, err: %v", first, err)
+	}
+	if set.Size() != 5 {
+		t.Fatalf("expected original set to be unchanged, got %v", set.ToArray())
+	}
+}
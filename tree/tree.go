@@ -0,0 +1,92 @@
+// Package tree provides sorted collections backed by a red-black tree.
+package tree
+
+import (
+	"iter"
+
+	"github.com/bsladewski/gollections"
+)
+
+// A SortedSetOf is a set that keeps its elements ordered by a comparator.
+// Iteration, ToArray and set operations visit elements in ascending order.
+type SortedSetOf[T any] interface {
+	gollections.SetOf[T]
+	// Ceiling gets the smallest element greater than or equal to the supplied value.
+	Ceiling(value T) (T, error)
+	// Descending gets a sequence over the elements of the set in descending order.
+	Descending() iter.Seq[T]
+	// First gets the smallest element in the set.
+	First() (T, error)
+	// Floor gets the largest element less than or equal to the supplied value.
+	Floor(value T) (T, error)
+	// HeadSet gets a new set of the elements strictly less than the supplied value.
+	// The result is a copy, not a view: changes to either set are not reflected in the other.
+	HeadSet(to T) SortedSetOf[T]
+	// Higher gets the smallest element strictly greater than the supplied value.
+	Higher(value T) (T, error)
+	// Last gets the largest element in the set.
+	Last() (T, error)
+	// Lower gets the largest element strictly less than the supplied value.
+	Lower(value T) (T, error)
+	// SubSet gets a new set of the elements from the first value, inclusive, to the second
+	// value, exclusive. The result is a copy, not a view: changes to either set are not reflected
+	// in the other.
+	SubSet(from, to T) SortedSetOf[T]
+	// TailSet gets a new set of the elements greater than or equal to the supplied value.
+	// The result is a copy, not a view: changes to either set are not reflected in the other.
+	TailSet(from T) SortedSetOf[T]
+}
+
+// An Entry is a key/value pair held by a SortedMapOf.
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// A SortedMapOf is a key/value store that keeps its entries ordered by a comparator on the keys.
+type SortedMapOf[K, V any] interface {
+	// All gets a sequence over the entries of the map in ascending key order.
+	All() iter.Seq2[K, V]
+	// Ceiling gets the entry with the smallest key greater than or equal to the supplied key.
+	Ceiling(key K) (Entry[K, V], error)
+	// Clear removes all entries from the map.
+	Clear()
+	// ContainsKey checks if the map has an entry for the supplied key.
+	ContainsKey(key K) bool
+	// Descending gets a sequence over the entries of the map in descending key order.
+	Descending() iter.Seq2[K, V]
+	// First gets the entry with the smallest key.
+	First() (Entry[K, V], error)
+	// Floor gets the entry with the largest key less than or equal to the supplied key.
+	Floor(key K) (Entry[K, V], error)
+	// Get retrieves the value for the supplied key. Returns an error if no such entry exists.
+	Get(key K) (V, error)
+	// HeadMap gets a new map of the entries with keys strictly less than the supplied key.
+	// The result is a copy, not a view: changes to either map are not reflected in the other.
+	HeadMap(to K) SortedMapOf[K, V]
+	// Higher gets the entry with the smallest key strictly greater than the supplied key.
+	Higher(key K) (Entry[K, V], error)
+	// IsEmpty checks if the map contains no entries.
+	IsEmpty() bool
+	// Keys gets a sequence over the keys of the map in ascending order.
+	Keys() iter.Seq[K]
+	// Last gets the entry with the largest key.
+	Last() (Entry[K, V], error)
+	// Lower gets the entry with the largest key strictly less than the supplied key.
+	Lower(key K) (Entry[K, V], error)
+	// Put adds or updates an entry in the map.
+	Put(key K, value V)
+	// Remove deletes a single entry from the map.
+	Remove(key K)
+	// Size gets the number of entries in the map.
+	Size() int
+	// SubMap gets a new map of the entries with keys from the first key, inclusive, to the
+	// second key, exclusive. The result is a copy, not a view: changes to either map are not
+	// reflected in the other.
+	SubMap(from, to K) SortedMapOf[K, V]
+	// TailMap gets a new map of the entries with keys greater than or equal to the supplied key.
+	// The result is a copy, not a view: changes to either map are not reflected in the other.
+	TailMap(from K) SortedMapOf[K, V]
+	// Values gets a sequence over the values of the map in ascending key order.
+	Values() iter.Seq[V]
+}
@@ -1,43 +1,44 @@
 // Package trie provides an implemenation of a trie data structure.
 package trie
 
-// A Trie is a set that is optimized for working with strings.
-type Trie interface {
+// A TrieOf is a set that is optimized for working with strings.
+// The type parameter allows tries over named string types, e.g. TrieOf[string] or TrieOf[Word].
+type TrieOf[S ~string] interface {
 	// Add inserts new values into the trie.
-	Add(values ...string)
+	Add(values ...S)
 	// Complete returns all strings that complete the supplied prefix string.
 	// If no relevant strings exist, the resulting array will be empty.
-	Complete(prefix string) []string
+	Complete(prefix S) []S
 	// Contains checks if the trie contains all specified values.
-	Contains(values ...string) bool
+	Contains(values ...S) bool
 	// Remove deletes the specified values from the trie.
-	Remove(values ...string)
+	Remove(values ...S)
 }
 
 // A trie is used to quickly check for and retrieve strings.
-type trie struct {
+type trie[S ~string] struct {
 	value    rune
-	children map[rune]*trie
+	children map[rune]*trie[S]
 }
 
 // adds the supplied string to the trie character by character.
-func (t *trie) add(value []rune, index int) {
+func (t *trie[S]) add(value []rune, index int) {
 	if index >= len(value) {
-		t.children[0] = &trie{}
+		t.children[0] = &trie[S]{}
 		return
 	}
 	current := value[index]
 	node, ok := t.children[current]
 	if !ok {
-		node = &trie{value: current, children: map[rune]*trie{}}
+		node = &trie[S]{value: current, children: map[rune]*trie[S]{}}
 		t.children[current] = node
 	}
 	node.add(value, index+1)
 }
 
-func (t *trie) Add(values ...string) {
+func (t *trie[S]) Add(values ...S) {
 	if t.children == nil {
-		t.children = map[rune]*trie{}
+		t.children = map[rune]*trie[S]{}
 	}
 	for _, value := range values {
 		t.add([]rune(value), 0)
@@ -46,7 +47,7 @@ func (t *trie) Add(values ...string) {
 
 // get finds a node in the trie using the supplied value as a path.
 // Returns nil if no such node is found.
-func (t *trie) get(value []rune, index int) *trie {
+func (t *trie[S]) get(value []rune, index int) *trie[S] {
 	if index == len(value) {
 		return t
 	}
@@ -59,13 +60,13 @@ func (t *trie) get(value []rune, index int) *trie {
 
 // traverse returns all strings that begin with the specified prefix.
 // If no relevant strings exist, the resulting array will be empty.
-func (t *trie) traverse(prefix string, first bool) []string {
+func (t *trie[S]) traverse(prefix S, first bool) []S {
 	if !first && t.value == 0 {
-		return []string{prefix}
+		return []S{prefix}
 	}
-	values := []string{}
+	values := []S{}
 	if !first {
-		prefix += string(t.value)
+		prefix += S(t.value)
 	}
 	for _, v := range t.children {
 		values = append(values, v.traverse(prefix, false)...)
@@ -73,16 +74,16 @@ func (t *trie) traverse(prefix string, first bool) []string {
 	return values
 }
 
-func (t *trie) Complete(prefix string) []string {
+func (t *trie[S]) Complete(prefix S) []S {
 	node := t.get([]rune(prefix), 0)
 	if node == nil {
-		return []string{}
+		return []S{}
 	}
 	return node.traverse(prefix, true)
 }
 
 // contains checks if the trie contains the specified value.
-func (t *trie) contains(value []rune, index int) bool {
+func (t *trie[S]) contains(value []rune, index int) bool {
 	if index == len(value) {
 		_, ok := t.children[0]
 		return ok
@@ -94,7 +95,7 @@ func (t *trie) contains(value []rune, index int) bool {
 	return node.contains(value, index+1)
 }
 
-func (t *trie) Contains(values ...string) bool {
+func (t *trie[S]) Contains(values ...S) bool {
 	for _, value := range values {
 		if !t.contains([]rune(value), 0) {
 			return false
@@ -105,7 +106,7 @@ func (t *trie) Contains(values ...string) bool {
 
 // remove deletes the specified value from the trie.
 // Returns true if the node should be removed from its parent.
-func (t *trie) remove(value []rune, index int) bool {
+func (t *trie[S]) remove(value []rune, index int) bool {
 	if index == len(value) {
 		delete(t.children, 0)
 		return len(t.children) == 0
@@ -120,13 +121,25 @@ func (t *trie) remove(value []rune, index int) bool {
 	return len(t.children) == 0
 }
 
-func (t *trie) Remove(values ...string) {
+func (t *trie[S]) Remove(values ...S) {
 	for _, value := range values {
 		t.remove([]rune(value), 0)
 	}
 }
 
-// NewTrie initializes a new trie.
+// NewTrieOf initializes a new trie.
+func NewTrieOf[S ~string]() TrieOf[S] {
+	return &trie[S]{}
+}
+
+// A Trie is a set of strings that is optimized for working with strings.
+//
+// Deprecated: Use TrieOf, which also allows tries over named string types.
+type Trie = TrieOf[string]
+
+// NewTrie initializes a new trie of strings.
+//
+// Deprecated: Use NewTrieOf, which also allows tries over named string types.
 func NewTrie() Trie {
-	return &trie{}
+	return NewTrieOf[string]()
 }
@@ -56,3 +56,16 @@ func TestTrie(t *testing.T) {
 		t.Fatalf("expected %v, got %v", expected, got)
 	}
 }
+
+// TestTrieNamedType tests a trie over a named string type.
+func TestTrieNamedType(t *testing.T) {
+	type word string
+	tr := trie.NewTrieOf[word]()
+	tr.Add("go", "gopher", "rust")
+	expected := []word{"go", "gopher"}
+	got := tr.Complete("go")
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}